@@ -0,0 +1,105 @@
+package nymeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Defaults used when a Client is not given WithBulkChunkSize or
+// WithBulkConcurrency.
+const (
+	DefaultBulkChunkSize   = 100
+	DefaultBulkConcurrency = 4
+)
+
+// WithBulkChunkSize sets how many inputs a bulk call sends per request.
+func WithBulkChunkSize(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return ErrInvalidParameters
+		}
+
+		c.chunkSize = n
+		return nil
+	}
+}
+
+// WithBulkConcurrency sets how many chunks of a bulk call are in flight at
+// once.
+func WithBulkConcurrency(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return ErrInvalidParameters
+		}
+
+		c.concurrency = n
+		return nil
+	}
+}
+
+// ChunkError reports the failure of the inputs [Start, End) of a bulk call.
+type ChunkError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+func (e ChunkError) Error() string {
+	return fmt.Sprintf("items %d-%d: %v", e.Start, e.End-1, e.Err)
+}
+
+// BulkError is returned by bulk calls when one or more chunks failed. The
+// results of the other chunks are still returned.
+type BulkError struct {
+	Chunks []ChunkError // sorted by Start
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Chunks))
+
+	for i, c := range e.Chunks {
+		msgs[i] = c.Error()
+	}
+
+	return fmt.Sprintf("error: %d bulk chunk(s) failed: %s", len(e.Chunks), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of every failed chunk, so errors.Is matches any
+// of them.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+
+	for i, c := range e.Chunks {
+		errs[i] = c.Err
+	}
+
+	return errs
+}
+
+// BulkLimits returns the chunk size and concurrency of the client's bulk
+// calls.
+func (c *Client) BulkLimits() (chunkSize, concurrency int) {
+	size, concurrency := c.chunkSize, c.concurrency
+
+	if size <= 0 {
+		size = DefaultBulkChunkSize
+	}
+
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	return size, concurrency
+}
+
+// ChunkErrors returns the failed chunks of err, if it is a *BulkError.
+func ChunkErrors(err error) []ChunkError {
+	var bulkErr *BulkError
+
+	if errors.As(err, &bulkErr) {
+		return bulkErr.Chunks
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package nymeria_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+// addresses seeds srv with n valid addresses and returns them as bulk inputs
+// carrying their position as metadata.
+func addresses(srv *nymeriatest.Server, n int) []email.BulkVerifyParams {
+	var params []email.BulkVerifyParams
+
+	for i := 0; i < n; i++ {
+		address := fmt.Sprintf("dev%d@nymeria.io", i)
+		srv.AddVerification(address, email.Verification{Result: "valid"})
+		params = append(params, email.BulkVerifyParams{Email: address, MetaData: i})
+	}
+
+	return params
+}
+
+func TestBulkChunks(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	params := addresses(srv, 250)
+
+	results, err := email.NewService(srv.Client(nymeria.WithBulkChunkSize(100))).BulkVerify(params...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(srv.RequestsTo("/email/verify/bulk")); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+
+	if len(results) != len(params) {
+		t.Fatalf("got %d results, want %d", len(results), len(params))
+	}
+
+	for i, r := range results {
+		if r.Index != i || r.Email != params[i].Email || !r.OK() {
+			t.Errorf("result %d: got index %d, email %q, err %v", i, r.Index, r.Email, r.Err)
+		}
+	}
+}
+
+func TestBulkChunkFailure(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	params := addresses(srv, 250)
+	srv.Inject(nymeriatest.Fault{Path: "/email/verify/bulk", Call: 2, Status: http.StatusInternalServerError})
+
+	client := srv.Client(nymeria.WithBulkChunkSize(100), nymeria.WithBulkConcurrency(1))
+	results, err := email.NewService(client).BulkVerify(params...)
+
+	var bulkErr *nymeria.BulkError
+
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("got %v, want a *BulkError", err)
+	}
+
+	chunks := nymeria.ChunkErrors(err)
+
+	if len(chunks) != 1 || chunks[0].Start != 100 || chunks[0].End != 200 {
+		t.Fatalf("got failed chunks %v, want [100, 200)", chunks)
+	}
+
+	if !errors.Is(err, nymeria.ErrServerError) {
+		t.Errorf("%v does not match ErrServerError", err)
+	}
+
+	for i, r := range results {
+		if failed := i >= 100 && i < 200; failed == r.OK() {
+			t.Errorf("result %d: got OK %v, err %v", i, r.OK(), r.Err)
+		}
+	}
+}
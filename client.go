@@ -0,0 +1,299 @@
+package nymeria
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Client holds the configuration used to talk to the Nymeria API. A Client is
+// safe for concurrent use and should be reused rather than created per call.
+type Client struct {
+	apiKey     string
+	baseURL    string
+	version    string
+	userAgent  string
+	timeout    time.Duration
+	httpClient *http.Client
+	retry      RetryPolicy
+	limits     limiter
+	usage      *UsageTracker
+	middleware []Middleware
+	logger     *slog.Logger
+
+	chunkSize   int
+	concurrency int
+
+	usageHeaders *UsageHeaders
+
+	instrumentation Instrumentation
+}
+
+// Option configures a Client.
+type Option func(*Client) error
+
+// DefaultClient is used by the package-level functions in person, company and
+// email. It reads ApiKey and HTTPClient at call time so existing programs that
+// set nymeria.ApiKey keep working.
+var DefaultClient = &Client{
+	baseURL:    BaseURL,
+	userAgent:  UserAgent,
+	httpClient: &HTTPClient,
+}
+
+// NewClient returns a Client configured with the given options. Unset values
+// fall back to the package defaults.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		baseURL:    BaseURL,
+		userAgent:  UserAgent,
+		httpClient: &HTTPClient,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.version) > 0 {
+		c.baseURL = versionedURL(c.baseURL, c.version)
+	}
+
+	if c.timeout > 0 || len(c.middleware) > 0 {
+		hc := *c.httpClient
+
+		if c.timeout > 0 {
+			hc.Timeout = c.timeout
+		}
+
+		hc.Transport = chain(hc.Transport, c.middleware)
+		c.httpClient = &hc
+	}
+
+	return c, nil
+}
+
+// WithAPIKey sets the API key used for authenticated requests.
+func WithAPIKey(key string) Option {
+	return func(c *Client) error {
+		c.apiKey = key
+		return nil
+	}
+}
+
+// WithBaseURL sets the URL every endpoint path is appended to, such as a
+// local stand-in ("http://localhost:8080/api/v4") or an egress proxy path. It
+// must be an absolute http or https URL without a query or fragment.
+func WithBaseURL(u string) Option {
+	return func(c *Client) error {
+		base, err := ParseBaseURL(u)
+
+		if err != nil {
+			return err
+		}
+
+		c.baseURL = base
+		return nil
+	}
+}
+
+// WithAPIVersion selects the API version, such as "4". A trailing "/v<N>"
+// segment of the base URL is replaced; otherwise "/v<version>" is appended.
+func WithAPIVersion(v string) Option {
+	return func(c *Client) error {
+		v = strings.TrimPrefix(v, "v")
+
+		if !versionNumber.MatchString(v) {
+			return fmt.Errorf("%w: api version %q", ErrInvalidParameters, v)
+		}
+
+		c.version = v
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to perform requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) error {
+		if hc == nil {
+			return ErrInvalidParameters
+		}
+
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout of each request. The HTTP client is
+// copied so a shared client is never modified; the same applies to
+// WithMiddleware.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		c.timeout = d
+		return nil
+	}
+}
+
+// BaseURL returns the URL every endpoint path is appended to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// key returns the configured API key, falling back to the package-level ApiKey.
+func (c *Client) key() string {
+	if len(c.apiKey) > 0 {
+		return c.apiKey
+	}
+
+	return ApiKey
+}
+
+// NewRequest builds an authenticated request for the given endpoint path.
+func (c *Client) NewRequest(method, endpoint string, data io.Reader) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, endpoint, data)
+}
+
+// NewRequestWithContext is like NewRequest but the request is bound to ctx, so
+// cancelling ctx aborts the call.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, endpoint string, data io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.baseURL, endpoint), data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = http.Header{
+		"X-Api-Key":  []string{c.key()},
+		"User-Agent": []string{c.userAgent},
+	}
+
+	return req, nil
+}
+
+// Do sends the request using the client's HTTP client, retrying according to
+// the client's RetryPolicy. Non-2xx responses are closed and reported as an
+// *APIError.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	attempts := c.retry.attempts(req)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req)
+
+		if err == nil || attempt >= attempts || !c.retry.retryable(err) {
+			return resp, err
+		}
+
+		if err := sleep(req.Context(), c.retry.backoff(attempt, err)); err != nil {
+			return nil, err
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single attempt, waiting on the client's rate limits first.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	endpoint := c.endpoint(req)
+
+	if err := c.limits.wait(req.Context(), endpoint); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logAttempt(req.Context(), req, resp, start, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	observeStatus(req.Context(), resp.StatusCode)
+	c.limits.observe(endpoint, resp.StatusCode)
+	c.recordUsage(req.Context(), endpoint, resp)
+
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// endpoint returns the request path relative to the client's base URL, such
+// as "/person/enrich".
+func (c *Client) endpoint(req *http.Request) string {
+	base, err := url.Parse(c.baseURL)
+
+	if err != nil {
+		return req.URL.Path
+	}
+
+	return strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(base.Path, "/"))
+}
+
+// rewind returns a copy of req with a fresh body so it can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.Body = body
+	}
+
+	return r, nil
+}
+
+// ParseBaseURL validates u as a base URL and returns it without a trailing
+// slash.
+func ParseBaseURL(u string) (string, error) {
+	parsed, err := url.Parse(u)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: base url %q: %v", ErrInvalidParameters, u, err)
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return "", fmt.Errorf("%w: base url %q must be an absolute http or https url", ErrInvalidParameters, u)
+	}
+
+	if len(parsed.RawQuery) > 0 || len(parsed.Fragment) > 0 {
+		return "", fmt.Errorf("%w: base url %q must not have a query or fragment", ErrInvalidParameters, u)
+	}
+
+	return strings.TrimSuffix(u, "/"), nil
+}
+
+var (
+	versionNumber  = regexp.MustCompile(`^[0-9]+$`)
+	versionSegment = regexp.MustCompile(`/v[0-9]+$`)
+)
+
+// versionedURL points base at the given API version.
+func versionedURL(base, version string) string {
+	if versionSegment.MatchString(base) {
+		return versionSegment.ReplaceAllString(base, "/v"+version)
+	}
+
+	return base + "/v" + version
+}
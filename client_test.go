@@ -0,0 +1,79 @@
+package nymeria_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+func TestParseBaseURL(t *testing.T) {
+	valid := map[string]string{
+		"https://www.nymeria.io/api/v4":   "https://www.nymeria.io/api/v4",
+		"https://www.nymeria.io/api/v4/":  "https://www.nymeria.io/api/v4",
+		"http://localhost:8080":           "http://localhost:8080",
+		"https://proxy.internal/nymeria/": "https://proxy.internal/nymeria",
+	}
+
+	for u, want := range valid {
+		got, err := nymeria.ParseBaseURL(u)
+
+		if err != nil || got != want {
+			t.Errorf("ParseBaseURL(%q) = %q, %v; want %q", u, got, err, want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"www.nymeria.io/api/v4",
+		"/api/v4",
+		"ftp://www.nymeria.io/api/v4",
+		"https://",
+		"https://www.nymeria.io/api/v4?api_key=secret",
+		"https://www.nymeria.io/api/v4#top",
+		"https://www.nymeria.io:port/api",
+	}
+
+	for _, u := range invalid {
+		if _, err := nymeria.ParseBaseURL(u); !errors.Is(err, nymeria.ErrInvalidParameters) {
+			t.Errorf("ParseBaseURL(%q) = %v, want ErrInvalidParameters", u, err)
+		}
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	tests := []struct {
+		base, version, want string
+	}{
+		{"", "4", "https://www.nymeria.io/api/v4"},
+		{"", "v5", "https://www.nymeria.io/api/v5"},
+		{"https://www.nymeria.io/api/v3/", "4", "https://www.nymeria.io/api/v4"},
+		{"http://localhost:8080", "4", "http://localhost:8080/v4"},
+		{"https://proxy.internal/v4x", "5", "https://proxy.internal/v4x/v5"},
+	}
+
+	for _, test := range tests {
+		opts := []nymeria.Option{nymeria.WithAPIVersion(test.version)}
+
+		if len(test.base) > 0 {
+			opts = append(opts, nymeria.WithBaseURL(test.base))
+		}
+
+		c, err := nymeria.NewClient(opts...)
+
+		if err != nil {
+			t.Errorf("%q with version %q: %v", test.base, test.version, err)
+			continue
+		}
+
+		if got := c.BaseURL(); got != test.want {
+			t.Errorf("%q with version %q: got %q, want %q", test.base, test.version, got, test.want)
+		}
+	}
+
+	for _, v := range []string{"", "v", "four", "4.1", "v-4"} {
+		if _, err := nymeria.NewClient(nymeria.WithAPIVersion(v)); !errors.Is(err, nymeria.ErrInvalidParameters) {
+			t.Errorf("version %q: got %v, want ErrInvalidParameters", v, err)
+		}
+	}
+}
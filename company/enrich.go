@@ -1,14 +1,13 @@
 package company
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
 type EnrichParams struct {
@@ -47,44 +46,40 @@ func (e EnrichParams) URL() string {
 }
 
 func Enrich(params EnrichParams) (*Company, error) {
-	if params.Invalid() {
-		return nil, nymeria.ErrInvalidParameters
-	}
+	return std().Enrich(params)
+}
+
+func EnrichContext(ctx context.Context, params EnrichParams) (*Company, error) {
+	return std().EnrichContext(ctx, params)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/company/enrich?%s", params.URL()), nil)
+func (s *Service) Enrich(params EnrichParams) (*Company, error) {
+	return s.EnrichContext(context.Background(), params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) EnrichContext(ctx context.Context, params EnrichParams) (*Company, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "company.Enrich", Endpoint: "/company/enrich"})
 
-	resp, err := nymeria.Client.Do(req)
+	record, err := s.enrich(ctx, params)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	call.End(1, nil)
 
-		return nil, nymeria.ErrServerError
-	}
-
-	defer resp.Body.Close()
-
-	bs, err := io.ReadAll(resp.Body)
+	return record, nil
+}
 
-	if err != nil {
-		return nil, err
+func (s *Service) enrich(ctx context.Context, params EnrichParams) (*Company, error) {
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
 	}
 
-	var response struct {
-		Status int     `json:"status"`
-		Data   Company `json:"data"`
-	}
+	response, err := transport.Fetch[Company](ctx, s.client, "GET", fmt.Sprintf("/company/enrich?%s", params.URL()), nil)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
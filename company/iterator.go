@@ -0,0 +1,72 @@
+package company
+
+import (
+	"context"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// SearchIterator walks every page of a search, de-duplicating records that
+// appear on more than one page:
+//
+//	it := company.NewSearchIterator(ctx, params, 500)
+//
+//	for it.Next() {
+//		log.Println(it.Company())
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	*nymeria.Pager[Company]
+}
+
+func NewSearchIterator(ctx context.Context, params SearchParams, max int) *SearchIterator {
+	return std().NewSearchIterator(ctx, params, max)
+}
+
+// NewSearchIterator returns an iterator over the results of params, starting
+// at params.Offset and stopping after max records (no cap when max <= 0).
+// Pages hold params.Limit records, or MaxSearchLimit when Limit is unset, and
+// never more than the records still needed to reach max.
+func (s *Service) NewSearchIterator(ctx context.Context, params SearchParams, max int) *SearchIterator {
+	if params.Limit <= 0 || params.Limit > MaxSearchLimit {
+		params.Limit = MaxSearchLimit
+	}
+
+	page := func(ctx context.Context, offset, limit int) ([]Company, int, error) {
+		p := params
+		p.Offset, p.Limit = offset, limit
+
+		response, err := s.page(ctx, p)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return response.Data, response.Total, nil
+	}
+
+	key := func(c Company) string {
+		return c.ID
+	}
+
+	return &SearchIterator{nymeria.NewPager(ctx, page, key, params.Offset, params.Limit, max)}
+}
+
+// Company returns the current record.
+func (it *SearchIterator) Company() Company {
+	return it.Value()
+}
+
+func SearchAll(ctx context.Context, params SearchParams, max int) ([]Company, error) {
+	return std().SearchAll(ctx, params, max)
+}
+
+// SearchAll collects the results of params across pages, up to max records
+// (no cap when max <= 0). On error the records collected so far are returned
+// alongside it.
+func (s *Service) SearchAll(ctx context.Context, params SearchParams, max int) ([]Company, error) {
+	return s.NewSearchIterator(ctx, params, max).All()
+}
@@ -1,23 +1,40 @@
 package company
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
+// SearchResult is one page of search results.
+type SearchResult struct {
+	Records  []Company
+	Total    int         // matching records across all pages
+	Offset   int         // position of the first record in Records
+	Limit    int         // page size used for the request
+	MetaData interface{} // metadata sent by the API as decoded JSON, if any
+}
+
+// HasMore reports whether records remain after this page.
+func (r SearchResult) HasMore() bool {
+	return r.Offset+len(r.Records) < r.Total
+}
+
+// MaxSearchLimit is the largest page of results the API returns.
+const MaxSearchLimit = 100
+
 type SearchParams struct {
 	Name     string
 	Location string
 	Country  string
 	Industry string
 	Size     string
-	Limit    int /* how many records to retrieve starting (default: 0) */
+	Limit    int /* how many records to retrieve (default: 10, max: 100) */
 	Offset   int /* from which record to start */
 }
 
@@ -25,14 +42,23 @@ func (s SearchParams) Invalid() bool {
 	return len(s.Name) == 0 && len(s.Location) == 0 && len(s.Country) == 0 && len(s.Industry) == 0 && len(s.Size) == 0
 }
 
-func (s SearchParams) URL() string {
-	if s.Limit <= 0 || s.Limit > 100 {
-		s.Limit = 10
+// limit returns the page size sent to the API.
+func (s SearchParams) limit() int {
+	if s.Limit <= 0 {
+		return 10
+	}
+
+	if s.Limit > MaxSearchLimit {
+		return MaxSearchLimit
 	}
 
+	return s.Limit
+}
+
+func (s SearchParams) URL() string {
 	var query strings.Builder
 
-	query.WriteString(fmt.Sprintf("limit=%d", s.Limit))
+	query.WriteString(fmt.Sprintf("limit=%d", s.limit()))
 	query.WriteString(fmt.Sprintf("&offset=%d", s.Offset))
 
 	if len(s.Name) > 0 {
@@ -58,47 +84,61 @@ func (s SearchParams) URL() string {
 	return query.String()
 }
 
-func Search(params SearchParams) ([]Company, error) {
-	if params.Invalid() {
-		return nil, nymeria.ErrInvalidParameters
-	}
+func Search(params SearchParams) (*SearchResult, error) {
+	return std().Search(params)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/company/search?%s", params.URL()), nil)
+func SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	return std().SearchContext(ctx, params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) Search(params SearchParams) (*SearchResult, error) {
+	return s.SearchContext(context.Background(), params)
+}
 
-	resp, err := nymeria.Client.Do(req)
+func (s *Service) SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	response, err := s.page(ctx, params)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	result := &SearchResult{
+		Records: response.Data,
+		Total:   response.Total,
+		Offset:  params.Offset,
+		Limit:   params.limit(),
+	}
 
-		return nil, nymeria.ErrServerError
+	if len(response.MetaData) > 0 {
+		if err := json.Unmarshal(response.MetaData, &result.MetaData); err != nil {
+			return nil, err
+		}
 	}
 
-	defer resp.Body.Close()
+	return result, nil
+}
 
-	bs, err := io.ReadAll(resp.Body)
+// page fetches one page of results, reported as a company.Search operation.
+func (s *Service) page(ctx context.Context, params SearchParams) (*transport.Envelope[[]Company], error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "company.Search", Endpoint: "/company/search"})
+
+	response, err := s.search(ctx, params)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	var response struct {
-		Status int       `json:"status"`
-		Data   []Company `json:"data"`
-	}
+	call.End(len(response.Data), nil)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
+	return response, nil
+}
+
+func (s *Service) search(ctx context.Context, params SearchParams) (*transport.Envelope[[]Company], error) {
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
 	}
 
-	return response.Data, nil
+	return transport.Fetch[[]Company](ctx, s.client, "GET", fmt.Sprintf("/company/search?%s", params.URL()), nil)
 }
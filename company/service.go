@@ -0,0 +1,31 @@
+package company
+
+import (
+	"context"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// CompanyService is the set of company calls. *Service implements it; depend on
+// the interface to substitute a fake such as the ones in nymeriatest.
+type CompanyService interface {
+	EnrichContext(ctx context.Context, params EnrichParams) (*Company, error)
+	SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error)
+}
+
+var _ CompanyService = (*Service)(nil)
+
+// Service exposes the company endpoints for a single nymeria.Client.
+type Service struct {
+	client *nymeria.Client
+}
+
+// NewService returns a Service that sends its requests through c.
+func NewService(c *nymeria.Client) *Service {
+	return &Service{client: c}
+}
+
+// std returns a Service bound to nymeria.DefaultClient.
+func std() *Service {
+	return NewService(nymeria.DefaultClient)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 )
 
+// Defaults used when a Client is not given WithBaseURL or WithAPIVersion.
 const (
 	ApiVersion = "4"
 	BaseURL    = "https://www.nymeria.io/api/v" + ApiVersion
@@ -15,7 +16,7 @@ const (
 )
 
 var (
-	// Timeout is the default timeout used by the HTTP client (default: 5 seconds).
+	// Timeout is the default timeout used by the HTTP client (default: 30 seconds).
 	Timeout = 30 * time.Second
 
 	// The API key that will be used for all authenticated requests.
@@ -25,35 +26,35 @@ var (
 	ErrBadRequest             = fmt.Errorf(`error: bad request; perhaps your parameters were wrong`)
 	ErrAuthenticationRequired = fmt.Errorf(`error: invalid or unauthorized api key detected`)
 	ErrPaymentRequired        = fmt.Errorf(`error: payment required; perhaps your plan expired or was exhausted`)
+	ErrForbidden              = fmt.Errorf(`error: api key is not permitted to use this resource`)
 	ErrNotFound               = fmt.Errorf(`error: resource not found`)
+	ErrRateLimited            = fmt.Errorf(`error: rate limit exceeded; slow down and try again later`)
 	ErrServerError            = fmt.Errorf(`error: server error detected`)
 
+	// ErrMap maps status codes to the sentinel errors APIError unwraps to.
+	// Statuses not listed here unwrap to ErrServerError.
 	ErrMap = map[int]error{
 		http.StatusBadRequest:      ErrBadRequest,
-		http.StatusNotFound:        ErrNotFound,
+		http.StatusUnauthorized:    ErrAuthenticationRequired,
 		http.StatusPaymentRequired: ErrPaymentRequired,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusTooManyRequests: ErrRateLimited,
 	}
 )
 
 var (
-	Client = http.Client{
+	// HTTPClient is the HTTP client used by DefaultClient and by clients that
+	// were not given one via WithHTTPClient. It was called Client before the
+	// Client type was introduced.
+	HTTPClient = http.Client{
 		Timeout: Timeout,
 	}
 )
 
+// Request builds an authenticated request using DefaultClient.
 func Request(method, endpoint string, data io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", BaseURL, endpoint), data)
-
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header = http.Header{
-		"X-Api-Key":  []string{ApiKey},
-		"User-Agent": []string{UserAgent},
-	}
-
-	return req, nil
+	return DefaultClient.NewRequest(method, endpoint, data)
 }
 
 func Normalize(s string) string {
@@ -0,0 +1,31 @@
+package email
+
+import (
+	"context"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// EmailService is the set of email calls. *Service implements it; depend on the
+// interface to substitute a fake such as the ones in nymeriatest.
+type EmailService interface {
+	VerifyContext(ctx context.Context, email string) (*Verification, error)
+	BulkVerifyContext(ctx context.Context, params ...BulkVerifyParams) ([]BulkVerifyResult, error)
+}
+
+var _ EmailService = (*Service)(nil)
+
+// Service exposes the email endpoints for a single nymeria.Client.
+type Service struct {
+	client *nymeria.Client
+}
+
+// NewService returns a Service that sends its requests through c.
+func NewService(c *nymeria.Client) *Service {
+	return &Service{client: c}
+}
+
+// std returns a Service bound to nymeria.DefaultClient.
+func std() *Service {
+	return NewService(nymeria.DefaultClient)
+}
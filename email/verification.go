@@ -6,3 +6,11 @@ type Verification struct {
 	SuggestedCorrection string   `json:"suggested_correction"`
 	ExecutionTime       int      `json:"execution_time"`
 }
+
+// bulkItem is one entry of a bulk verify response.
+type bulkItem struct {
+	Status   int           `json:"status"`
+	Message  string        `json:"message"`
+	MetaData interface{}   `json:"metadata"`
+	Data     *Verification `json:"data"`
+}
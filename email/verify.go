@@ -1,14 +1,12 @@
 package email
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
 type BulkVerifyParams struct {
@@ -16,54 +14,87 @@ type BulkVerifyParams struct {
 	MetaData interface{} `json:"metadata"`
 }
 
+// BulkVerifyResult is the outcome of one BulkVerifyParams. Results are
+// returned in input order.
+type BulkVerifyResult struct {
+	Index        int           // position of the input in the request
+	Email        string        // the normalized address that was verified
+	MetaData     interface{}   // the input's metadata
+	Status       int           // the item's status, e.g. 200 or 404
+	Err          error         // nil on success; an *nymeria.ItemError otherwise
+	Verification *Verification // set on success
+}
+
+// OK reports whether the address was verified.
+func (r BulkVerifyResult) OK() bool {
+	return r.Err == nil && r.Verification != nil
+}
+
 func Verify(email string) (*Verification, error) {
-	email = nymeria.Normalize(email)
+	return std().Verify(email)
+}
 
-	if len(email) == 0 {
-		return nil, nymeria.ErrInvalidParameters
-	}
+func VerifyContext(ctx context.Context, email string) (*Verification, error) {
+	return std().VerifyContext(ctx, email)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/email/verify?email=%s", url.QueryEscape(email)), nil)
+func (s *Service) Verify(email string) (*Verification, error) {
+	return s.VerifyContext(context.Background(), email)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) VerifyContext(ctx context.Context, email string) (*Verification, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "email.Verify", Endpoint: "/email/verify"})
 
-	resp, err := nymeria.Client.Do(req)
+	record, err := s.verify(ctx, email)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	call.End(1, nil)
 
-		return nil, nymeria.ErrServerError
-	}
-
-	defer resp.Body.Close()
+	return record, nil
+}
 
-	bs, err := io.ReadAll(resp.Body)
+func (s *Service) verify(ctx context.Context, email string) (*Verification, error) {
+	email = nymeria.Normalize(email)
 
-	if err != nil {
-		return nil, err
+	if len(email) == 0 {
+		return nil, nymeria.ErrInvalidParameters
 	}
 
-	var response struct {
-		Status int          `json:"status"`
-		Data   Verification `json:"data"`
-	}
+	response, err := transport.Fetch[Verification](ctx, s.client, "GET", fmt.Sprintf("/email/verify?email=%s", url.QueryEscape(email)), nil)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
 	return &response.Data, nil
 }
 
-func BulkVerify(params ...BulkVerifyParams) ([]Verification, error) {
+func BulkVerify(params ...BulkVerifyParams) ([]BulkVerifyResult, error) {
+	return std().BulkVerify(params...)
+}
+
+func BulkVerifyContext(ctx context.Context, params ...BulkVerifyParams) ([]BulkVerifyResult, error) {
+	return std().BulkVerifyContext(ctx, params...)
+}
+
+func (s *Service) BulkVerify(params ...BulkVerifyParams) ([]BulkVerifyResult, error) {
+	return s.BulkVerifyContext(context.Background(), params...)
+}
+
+func (s *Service) BulkVerifyContext(ctx context.Context, params ...BulkVerifyParams) ([]BulkVerifyResult, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "email.BulkVerify", Endpoint: "/email/verify/bulk", BulkSize: len(params)})
+
+	results, err := s.bulkVerify(ctx, params...)
+	call.End(transport.Succeeded(results), err)
+
+	return results, err
+}
+
+func (s *Service) bulkVerify(ctx context.Context, params ...BulkVerifyParams) ([]BulkVerifyResult, error) {
 	for i := range params {
 		params[i].Email = nymeria.Normalize(params[i].Email)
 	}
@@ -72,6 +103,43 @@ func BulkVerify(params ...BulkVerifyParams) ([]Verification, error) {
 		return nil, nymeria.ErrInvalidParameters
 	}
 
+	return bulkVerifyCall.Send(ctx, s.client, params)
+}
+
+// bulkVerifyCall describes the bulk verify endpoint.
+var bulkVerifyCall = transport.Bulk[BulkVerifyParams, bulkItem, BulkVerifyResult]{
+	Endpoint: "/email/verify/bulk",
+	Body: func(offset int, batch []BulkVerifyParams) (interface{}, error) {
+		for i := range batch {
+			batch[i].Email = nymeria.Normalize(batch[i].Email)
+		}
+
+		return verifyRequests(batch), nil
+	},
+	Correlate: correlate,
+	Result:    verifyResult,
+	Fail: func(index int, p BulkVerifyParams, err error) BulkVerifyResult {
+		return BulkVerifyResult{Index: index, Email: p.Email, MetaData: p.MetaData, Err: err}
+	},
+}
+
+// correlate matches bulk verify items to params by their echoed metadata, so
+// a reordered response can't attach one address's verification to another.
+// Items echoing no metadata fall back to their position.
+func correlate(params []BulkVerifyParams) *transport.Correlator[bulkItem] {
+	keys := make([]string, len(params))
+
+	for i, p := range params {
+		keys[i] = transport.MetadataKey(p.MetaData)
+	}
+
+	return transport.NewCorrelator(keys, func(v bulkItem) string {
+		return transport.MetadataKey(v.MetaData)
+	})
+}
+
+// verifyRequests builds the body of a bulk verify request.
+func verifyRequests(params []BulkVerifyParams) map[string]interface{} {
 	requests := []map[string]interface{}{}
 
 	for _, p := range params {
@@ -83,58 +151,46 @@ func BulkVerify(params ...BulkVerifyParams) ([]Verification, error) {
 		})
 	}
 
-	bs, err := json.Marshal(map[string]interface{}{
+	return map[string]interface{}{
 		"requests": requests,
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := nymeria.Request("POST", "/email/verify/bulk", bytes.NewBuffer(bs))
-
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := nymeria.Client.Do(req)
-
-	if err != nil {
-		return nil, err
 	}
+}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+// verifyResult builds the result of input p at index from its response item,
+// which is nil when the API returned none.
+func verifyResult(index int, p BulkVerifyParams, v *bulkItem) BulkVerifyResult {
+	result := BulkVerifyResult{Index: index, Email: p.Email, MetaData: p.MetaData}
 
-		return nil, nymeria.ErrServerError
+	if v == nil {
+		result.Err = nymeria.CheckItem(index, 0, "")
+		return result
 	}
 
-	defer resp.Body.Close()
+	result.Status = v.Status
+	result.Err = nymeria.CheckItem(index, v.Status, v.Message)
 
-	bs, err = io.ReadAll(resp.Body)
-
-	if err != nil {
-		return nil, err
+	if result.Err == nil {
+		result.Verification = v.Data
 	}
 
-	var response []struct {
-		Status int          `json:"status"`
-		Data   Verification `json:"data"`
-	}
+	return result
+}
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
-	}
+func BulkVerifyStream(ctx context.Context, in <-chan BulkVerifyParams, fn func(BulkVerifyResult) error) error {
+	return std().BulkVerifyStream(ctx, in, fn)
+}
 
-	var records []Verification
+// BulkVerifyStream verifies every address received from in, in chunks, and
+// calls fn with each result as soon as it is decoded. Results arrive in
+// completion order; use Index to place them. fn is never called concurrently.
+// Failed chunks are reported as results carrying the chunk's error and
+// returned as a *nymeria.BulkError once the stream is done; an error returned
+// by fn stops the stream and is returned.
+func (s *Service) BulkVerifyStream(ctx context.Context, in <-chan BulkVerifyParams, fn func(BulkVerifyResult) error) error {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "email.BulkVerifyStream", Endpoint: "/email/verify/bulk"})
 
-	for _, v := range response {
-		records = append(records, v.Data)
-	}
+	n, err := bulkVerifyCall.Stream(ctx, s.client, in, fn)
+	call.End(n, err)
 
-	return records, nil
+	return err
 }
@@ -0,0 +1,76 @@
+package email_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+)
+
+func TestBulkVerifyCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string // the result for rows 1 and 2; "" for none
+	}{
+		{
+			name: "reordered",
+			body: `{"data":[{"status":200,"metadata":{"row":2},"data":{"result":"invalid"}},{"status":200,"metadata":{"row":1},"data":{"result":"valid"}}]}`,
+			want: []string{"valid", "invalid"},
+		},
+		{
+			name: "mismatched metadata",
+			body: `{"data":[{"status":200,"metadata":{"row":9},"data":{"result":"invalid"}},{"status":200,"metadata":{"row":2},"data":{"result":"valid"}}]}`,
+			want: []string{"", "valid"},
+		},
+		{
+			name: "no metadata echoed",
+			body: `{"data":[{"status":200,"data":{"result":"valid"}},{"status":200,"data":{"result":"invalid"}}]}`,
+			want: []string{"valid", "invalid"},
+		},
+	}
+
+	params := []email.BulkVerifyParams{
+		{Email: "a@nymeria.io", MetaData: map[string]int{"row": 1}},
+		{Email: "b@nymeria.io", MetaData: map[string]int{"row": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c, err := nymeria.NewClient(nymeria.WithBaseURL(srv.URL))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := email.NewService(c).BulkVerify(params...)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, r := range results {
+				got := ""
+
+				if r.Verification != nil {
+					got = r.Verification.Result
+				}
+
+				if got != tt.want[i] {
+					t.Errorf("%s: got result %q, want %q", r.Email, got, tt.want[i])
+				}
+
+				if r.MetaData == nil || r.MetaData.(map[string]int)["row"] != i+1 {
+					t.Errorf("%s: metadata replaced with %v", r.Email, r.MetaData)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package nymeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBody caps how much of an error response body is kept on APIError.
+const maxErrorBody = 64 << 10
+
+// APIError is returned for every non-2xx response. It matches the sentinel
+// errors (ErrNotFound, ErrPaymentRequired, etc.) with errors.Is.
+type APIError struct {
+	StatusCode int
+	Message    string        // the server's error message, when one was sent
+	Body       []byte        // the raw response body (truncated to 64KiB)
+	RequestID  string        // the X-Request-Id response header, if present
+	RetryAfter time.Duration // parsed from the Retry-After header, if present
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+
+	if len(msg) == 0 {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s (status %d: %s)", e.Unwrap().Error(), e.StatusCode, msg)
+}
+
+// Unwrap returns the sentinel error matching the status code.
+func (e *APIError) Unwrap() error {
+	if err, ok := ErrMap[e.StatusCode]; ok {
+		return err
+	}
+
+	return ErrServerError
+}
+
+// CheckResponse returns nil for 2xx responses. Otherwise it reads and closes
+// the body and returns an *APIError describing the failure.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	bs, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    errorMessage(bs),
+		Body:       bs,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+func errorMessage(bs []byte) string {
+	var body struct {
+		Message string          `json:"message"`
+		Error   json.RawMessage `json:"error"`
+		Errors  []string        `json:"errors"`
+	}
+
+	if err := json.Unmarshal(bs, &body); err != nil {
+		return strings.TrimSpace(string(bs))
+	}
+
+	if len(body.Message) > 0 {
+		return body.Message
+	}
+
+	var s string
+
+	if err := json.Unmarshal(body.Error, &s); err == nil && len(s) > 0 {
+		return s
+	}
+
+	return strings.Join(body.Errors, "; ")
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(v string) time.Duration {
+	if len(v) == 0 {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// ItemError describes a failed item of a bulk call. It matches the sentinel
+// errors with errors.Is the same way APIError does.
+type ItemError struct {
+	Index      int // position of the item in the request
+	StatusCode int // the item's status; 0 if the API returned no result for it
+	Message    string
+}
+
+func (e *ItemError) Error() string {
+	msg := e.Message
+
+	if len(msg) == 0 {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s (item %d, status %d: %s)", e.Unwrap().Error(), e.Index, e.StatusCode, msg)
+}
+
+// Unwrap returns the sentinel error matching the status code.
+func (e *ItemError) Unwrap() error {
+	if err, ok := ErrMap[e.StatusCode]; ok {
+		return err
+	}
+
+	return ErrServerError
+}
+
+// CheckItem returns nil for a 2xx item status and an *ItemError otherwise.
+func CheckItem(index, status int, message string) error {
+	if status >= 200 && status < 300 {
+		return nil
+	}
+
+	if status == 0 && len(message) == 0 {
+		message = "no result returned for item"
+	}
+
+	return &ItemError{Index: index, StatusCode: status, Message: message}
+}
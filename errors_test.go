@@ -0,0 +1,89 @@
+package nymeria_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, nymeria.ErrAuthenticationRequired},
+		{http.StatusForbidden, nymeria.ErrForbidden},
+		{http.StatusTooManyRequests, nymeria.ErrRateLimited},
+		{http.StatusTeapot, nymeria.ErrServerError},
+	}
+
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	for _, test := range tests {
+		srv.ClearFaults()
+		srv.Inject(nymeriatest.Fault{
+			Status: test.status,
+			Header: http.Header{"X-Request-Id": {"req-1"}},
+			Body:   `{"message": "no"}`,
+		})
+
+		_, err := email.NewService(srv.Client()).Verify("dev@nymeria.io")
+
+		if !errors.Is(err, test.want) {
+			t.Errorf("status %d: %v does not match %v", test.status, err, test.want)
+		}
+
+		var apiErr *nymeria.APIError
+
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: got %T, want *APIError", test.status, err)
+		}
+
+		if apiErr.StatusCode != test.status || apiErr.Message != "no" || apiErr.RequestID != "req-1" {
+			t.Errorf("status %d: got %+v", test.status, apiErr)
+		}
+	}
+}
+
+func TestAPIErrorRetryAfter(t *testing.T) {
+	tests := []struct {
+		header   string
+		min, max time.Duration
+	}{
+		{"", 0, 0},
+		{"7", 7 * time.Second, 7 * time.Second},
+		{"-3", 0, 0},
+		{"soon", 0, 0},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 58 * time.Minute, time.Hour},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0},
+	}
+
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	for _, test := range tests {
+		srv.ClearFaults()
+		srv.Inject(nymeriatest.Fault{
+			Status: http.StatusTooManyRequests,
+			Header: http.Header{"Retry-After": {test.header}},
+		})
+
+		_, err := email.NewService(srv.Client()).Verify("dev@nymeria.io")
+
+		var apiErr *nymeria.APIError
+
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Retry-After %q: got %T, want *APIError", test.header, err)
+		}
+
+		if apiErr.RetryAfter < test.min || apiErr.RetryAfter > test.max {
+			t.Errorf("Retry-After %q: got %v, want between %v and %v", test.header, apiErr.RetryAfter, test.min, test.max)
+		}
+	}
+}
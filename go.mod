@@ -1,3 +1,3 @@
 module github.com/nymeria-io/nymeria.go
 
-go 1.11
+go 1.21
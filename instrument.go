@@ -0,0 +1,186 @@
+package nymeria
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Operation describes one call made through a service, such as person.Enrich.
+type Operation struct {
+	Name     string `json:"name"`      // e.g. "person.Enrich"
+	Endpoint string `json:"endpoint"`  // e.g. "/person/enrich"
+	BulkSize int    `json:"bulk_size"` // number of inputs for bulk calls, 0 otherwise
+}
+
+// OperationResult describes how an Operation finished.
+type OperationResult struct {
+	StatusCode int           `json:"status_code"` // last HTTP status seen, 0 if no response arrived
+	Records    int           `json:"records"`     // records returned to the caller
+	Duration   time.Duration `json:"duration"`
+	Err        error         `json:"-"`
+}
+
+// Instrumentation receives a span for every operation. Adapters can bridge it
+// to OpenTelemetry, Prometheus or similar without this package depending on
+// them.
+type Instrumentation interface {
+	Start(ctx context.Context, op Operation) (context.Context, Span)
+}
+
+// Span is ended exactly once when its operation finishes.
+type Span interface {
+	End(OperationResult)
+}
+
+// WithInstrumentation reports every operation made by the client to i.
+func WithInstrumentation(i Instrumentation) Option {
+	return func(c *Client) error {
+		c.instrumentation = i
+		return nil
+	}
+}
+
+type callKey struct{}
+
+// Call tracks an in-flight operation. A nil *Call is valid and does nothing.
+type Call struct {
+	span   Span
+	start  time.Time
+	status int32
+}
+
+// Start begins an operation. The returned context must be used for the
+// operation's requests so their status codes are attributed to it.
+func (c *Client) Start(ctx context.Context, op Operation) (context.Context, *Call) {
+	if c.instrumentation == nil {
+		return ctx, nil
+	}
+
+	ctx, span := c.instrumentation.Start(ctx, op)
+	call := &Call{span: span, start: time.Now()}
+
+	return context.WithValue(ctx, callKey{}, call), call
+}
+
+// End finishes the operation with the number of records returned and its
+// error, if any.
+func (c *Call) End(records int, err error) {
+	if c == nil {
+		return
+	}
+
+	status := int(atomic.LoadInt32(&c.status))
+
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+
+	c.span.End(OperationResult{
+		StatusCode: status,
+		Records:    records,
+		Duration:   time.Since(c.start),
+		Err:        err,
+	})
+}
+
+// observeStatus records the status of a response on the operation in ctx.
+func observeStatus(ctx context.Context, status int) {
+	if call, ok := ctx.Value(callKey{}).(*Call); ok {
+		atomic.StoreInt32(&call.status, int32(status))
+	}
+}
+
+// SpanRecord is an operation captured by MemoryInstrumentation.
+type SpanRecord struct {
+	Operation
+	OperationResult
+}
+
+// OperationMetrics aggregates the spans of one operation name.
+type OperationMetrics struct {
+	Calls    int           `json:"calls"`
+	Errors   int           `json:"errors"`
+	Records  int           `json:"records"`
+	BulkSize int           `json:"bulk_size"`
+	Duration time.Duration `json:"duration"`
+	Statuses map[int]int   `json:"statuses"`
+}
+
+// MemoryInstrumentation keeps every finished span in memory. It is meant for
+// tests and debugging; its zero value is ready to use.
+type MemoryInstrumentation struct {
+	mu    sync.Mutex
+	spans []SpanRecord
+}
+
+// NewMemoryInstrumentation returns an empty MemoryInstrumentation.
+func NewMemoryInstrumentation() *MemoryInstrumentation {
+	return &MemoryInstrumentation{}
+}
+
+func (m *MemoryInstrumentation) Start(ctx context.Context, op Operation) (context.Context, Span) {
+	return ctx, &memorySpan{m: m, op: op}
+}
+
+// Spans returns the finished spans in the order they ended.
+func (m *MemoryInstrumentation) Spans() []SpanRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]SpanRecord(nil), m.spans...)
+}
+
+// Metrics aggregates the finished spans per operation name.
+func (m *MemoryInstrumentation) Metrics() map[string]OperationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := map[string]OperationMetrics{}
+
+	for _, s := range m.spans {
+		v := metrics[s.Name]
+
+		if v.Statuses == nil {
+			v.Statuses = map[int]int{}
+		}
+
+		v.Calls++
+		v.Records += s.Records
+		v.BulkSize += s.BulkSize
+		v.Duration += s.Duration
+		v.Statuses[s.StatusCode]++
+
+		if s.Err != nil {
+			v.Errors++
+		}
+
+		metrics[s.Name] = v
+	}
+
+	return metrics
+}
+
+// Reset discards every recorded span.
+func (m *MemoryInstrumentation) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spans = nil
+}
+
+type memorySpan struct {
+	m  *MemoryInstrumentation
+	op Operation
+}
+
+func (s *memorySpan) End(r OperationResult) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	s.m.spans = append(s.m.spans, SpanRecord{Operation: s.op, OperationResult: r})
+}
@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Result is the per-input outcome of a bulk call.
+type Result interface {
+	OK() bool
+}
+
+// Bulk describes a bulk endpoint: how a batch of In inputs is sent, how the
+// Item entries of the response are paired with the inputs and how each pair
+// becomes an Out result. It implements chunked and streaming calls once for
+// every service.
+type Bulk[In, Item any, Out Result] struct {
+	Endpoint string
+
+	// Body returns the request body for batch, whose first input is at offset.
+	// It may normalize batch in place. An error fails the batch unsent.
+	Body func(offset int, batch []In) (interface{}, error)
+
+	// Correlate pairs the items of a response with batch. Nil pairs them by
+	// position.
+	Correlate func(batch []In) *Correlator[Item]
+
+	// Result builds the result of the input at index from its response item,
+	// which is nil when the API returned none.
+	Result func(index int, input In, item *Item) Out
+
+	// Fail builds the result of the input at index when its batch failed.
+	Fail func(index int, input In, err error) Out
+}
+
+func (b Bulk[In, Item, Out]) correlate(batch []In) *Correlator[Item] {
+	if b.Correlate == nil {
+		return NewCorrelator[Item](make([]string, len(batch)), nil)
+	}
+
+	return b.Correlate(batch)
+}
+
+// Send sends params in chunks (see Chunks) and returns their results
+// in input order. Inputs of a failed chunk carry its error, which is also
+// returned as part of a *nymeria.BulkError.
+func (b Bulk[In, Item, Out]) Send(ctx context.Context, c *nymeria.Client, params []In) ([]Out, error) {
+	results := make([]Out, len(params))
+
+	err := Chunks(ctx, c, len(params), func(ctx context.Context, start, end int) error {
+		batch, out := params[start:end], results[start:end]
+
+		body, err := b.Body(start, batch)
+
+		if err != nil {
+			return err
+		}
+
+		response, err := Fetch[[]Item](ctx, c, "POST", b.Endpoint, body)
+
+		if err != nil {
+			return err
+		}
+
+		matches := b.correlate(batch)
+
+		for i := range response.Data {
+			if j, ok := matches.Add(i, response.Data[i]); ok {
+				out[j] = b.Result(start+j, batch[j], &response.Data[i])
+			}
+		}
+
+		return matches.Finish(func(j int, item *Item) error {
+			out[j] = b.Result(start+j, batch[j], item)
+			return nil
+		})
+	})
+
+	for _, chunk := range nymeria.ChunkErrors(err) {
+		for i := chunk.Start; i < chunk.End; i++ {
+			results[i] = b.Fail(i, params[i], chunk.Err)
+		}
+	}
+
+	return results, err
+}
+
+// Stream sends the inputs received from in in batches (see Batches) and calls
+// fn with each result as soon as its item is decoded. fn is never called
+// concurrently. Inputs of a failed batch are reported as results carrying its
+// error, and once every batch is done the failed batches are returned as a
+// *nymeria.BulkError, as Send does; a batch that failed part way is listed
+// with its whole range. An error returned by fn stops the stream and is
+// returned instead. n is the number of successful results.
+func (b Bulk[In, Item, Out]) Stream(ctx context.Context, c *nymeria.Client, in <-chan In, fn func(Out) error) (n int, err error) {
+	emit := Emitter(func(r Out) error {
+		if r.OK() {
+			n++
+		}
+
+		return fn(r)
+	})
+
+	var (
+		mu     sync.Mutex
+		failed []nymeria.ChunkError
+	)
+
+	err = Batches(ctx, c, in, func(ctx context.Context, offset int, batch []In) error {
+		matches := b.correlate(batch)
+
+		body, err := b.Body(offset, batch)
+
+		if err == nil {
+			err = Stream(ctx, c, "POST", b.Endpoint, body, func(i int, item Item) error {
+				j, ok := matches.Add(i, item)
+
+				if !ok {
+					return nil
+				}
+
+				return emit(b.Result(offset+j, batch[j], &item))
+			})
+		}
+
+		var cbErr *CallbackError
+
+		if errors.As(err, &cbErr) {
+			return err
+		}
+
+		if err != nil {
+			mu.Lock()
+			failed = append(failed, nymeria.ChunkError{Start: offset, End: offset + len(batch), Err: err})
+			mu.Unlock()
+		}
+
+		return matches.Finish(func(j int, item *Item) error {
+			if item == nil && err != nil {
+				return emit(b.Fail(offset+j, batch[j], err))
+			}
+
+			return emit(b.Result(offset+j, batch[j], item))
+		})
+	})
+
+	var cbErr *CallbackError
+
+	if errors.As(err, &cbErr) {
+		return n, cbErr.Err
+	}
+
+	if err != nil || len(failed) == 0 {
+		return n, err
+	}
+
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Start < failed[j].Start })
+
+	return n, &nymeria.BulkError{Chunks: failed}
+}
+
+// Succeeded counts the successful results.
+func Succeeded[T Result](results []T) int {
+	n := 0
+
+	for _, r := range results {
+		if r.OK() {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Chunks splits n inputs into chunks of the client's bulk chunk size and
+// calls fn for each chunk's range [start, end), with at most the client's bulk
+// concurrency in flight. It returns a *nymeria.BulkError listing the chunks
+// that failed or were never sent because ctx was done, or nil.
+func Chunks(ctx context.Context, c *nymeria.Client, n int, fn func(ctx context.Context, start, end int) error) error {
+	size, concurrency := c.BulkLimits()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []nymeria.ChunkError
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	fail := func(start, end int, err error) {
+		mu.Lock()
+		errs = append(errs, nymeria.ChunkError{Start: start, End: end, Err: err})
+		mu.Unlock()
+	}
+
+dispatch:
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(start, n, ctx.Err())
+			break dispatch
+		}
+
+		wg.Add(1)
+
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, start, end); err != nil {
+				fail(start, end, err)
+			}
+		}(start, end)
+	}
+
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Start < errs[j].Start })
+
+	return &nymeria.BulkError{Chunks: errs}
+}
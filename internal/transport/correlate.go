@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"encoding/json"
+)
+
+// Correlator pairs the items of a bulk response with the inputs they answer
+// when the API can't be trusted to keep the order of the request.
+//
+// An item carrying a key, such as a person ID or echoed metadata, is paired
+// with the first unclaimed input with the same key and dropped if there is
+// none; it never falls back to its position. An item without a key is paired
+// with the unclaimed input at its own position. When that input has a key the
+// pairing is held back until Finish, so a keyless item can't take an input a
+// later keyed item answers.
+type Correlator[T any] struct {
+	keys    []string
+	key     func(T) string
+	claimed []bool
+	held    map[int]T
+}
+
+// NewCorrelator returns a Correlator for inputs with the given keys, "" for
+// an input without one. key returns the key an item carries, or ""; a nil key
+// pairs every item by position.
+func NewCorrelator[T any](keys []string, key func(T) string) *Correlator[T] {
+	return &Correlator[T]{
+		keys:    keys,
+		key:     key,
+		claimed: make([]bool, len(keys)),
+		held:    map[int]T{},
+	}
+}
+
+// Add pairs the item at position i of the response. ok is false when the item
+// was dropped or held back until Finish.
+func (c *Correlator[T]) Add(i int, item T) (input int, ok bool) {
+	k := ""
+
+	if c.key != nil {
+		k = c.key(item)
+	}
+
+	if len(k) > 0 {
+		for j, key := range c.keys {
+			if !c.claimed[j] && key == k {
+				c.claimed[j] = true
+				return j, true
+			}
+		}
+
+		return -1, false
+	}
+
+	if i >= len(c.keys) || c.claimed[i] {
+		return -1, false
+	}
+
+	if len(c.keys[i]) > 0 {
+		c.held[i] = item
+		return -1, false
+	}
+
+	c.claimed[i] = true
+
+	return i, true
+}
+
+// Finish calls fn for every input that is still unclaimed, in order, with the
+// keyless item held back for it or nil when the response had none.
+func (c *Correlator[T]) Finish(fn func(input int, item *T) error) error {
+	for j := range c.keys {
+		if c.claimed[j] {
+			continue
+		}
+
+		c.claimed[j] = true
+
+		var item *T
+
+		if v, ok := c.held[j]; ok {
+			item = &v
+		}
+
+		if err := fn(j, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MetadataKey returns a canonical JSON encoding of metadata, so the value sent
+// and the value the API echoes compare equal, or "" for none. It keys the
+// Correlator of bulk endpoints that echo each input's metadata.
+func MetadataKey(metadata interface{}) string {
+	if metadata == nil {
+		return ""
+	}
+
+	bs, err := json.Marshal(metadata)
+
+	if err != nil {
+		return ""
+	}
+
+	var v interface{}
+
+	if err := json.Unmarshal(bs, &v); err != nil || v == nil {
+		return ""
+	}
+
+	if bs, err = json.Marshal(v); err != nil {
+		return ""
+	}
+
+	return string(bs)
+}
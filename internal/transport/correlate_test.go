@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	pos int
+	key string
+}
+
+func TestCorrelator(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		items []testItem
+		want  []int // response position paired with each input; -1 for none
+	}{
+		{
+			name:  "by position",
+			keys:  []string{"", ""},
+			items: []testItem{{0, ""}, {1, ""}},
+			want:  []int{0, 1},
+		},
+		{
+			name:  "reordered by key",
+			keys:  []string{"A", "B"},
+			items: []testItem{{0, "B"}, {1, "A"}},
+			want:  []int{1, 0},
+		},
+		{
+			name:  "keyless item never takes a claimed input",
+			keys:  []string{"A", "B"},
+			items: []testItem{{0, "B"}, {1, ""}},
+			want:  []int{-1, 0},
+		},
+		{
+			name:  "keyless item waits for later keyed items",
+			keys:  []string{"A", "B"},
+			items: []testItem{{0, ""}, {1, "A"}},
+			want:  []int{1, -1},
+		},
+		{
+			name:  "unknown key is dropped",
+			keys:  []string{"A", "B"},
+			items: []testItem{{0, "C"}, {1, "B"}},
+			want:  []int{-1, 1},
+		},
+		{
+			name:  "duplicate keys pair in order",
+			keys:  []string{"A", "A"},
+			items: []testItem{{0, "A"}, {1, "A"}},
+			want:  []int{0, 1},
+		},
+		{
+			name:  "missing items",
+			keys:  []string{"", "", ""},
+			items: []testItem{{0, ""}},
+			want:  []int{0, -1, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]int, len(tt.keys))
+			c := NewCorrelator(tt.keys, func(v testItem) string { return v.key })
+
+			for _, item := range tt.items {
+				if j, ok := c.Add(item.pos, item); ok {
+					got[j] = item.pos
+				}
+			}
+
+			err := c.Finish(func(j int, item *testItem) error {
+				got[j] = -1
+
+				if item != nil {
+					got[j] = item.pos
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got pairs %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataKey(t *testing.T) {
+	sent := map[string]interface{}{"row": 1, "sheet": "b"}
+	echoed := map[string]interface{}{"sheet": "b", "row": float64(1)}
+
+	if MetadataKey(sent) != MetadataKey(echoed) {
+		t.Errorf("%q and %q differ", MetadataKey(sent), MetadataKey(echoed))
+	}
+
+	if MetadataKey(sent) == MetadataKey(map[string]int{"row": 2}) {
+		t.Error("distinct metadata share a key")
+	}
+
+	for _, v := range []interface{}{nil, (*int)(nil), func() {}} {
+		if k := MetadataKey(v); len(k) > 0 {
+			t.Errorf("got key %q for %v", k, v)
+		}
+	}
+}
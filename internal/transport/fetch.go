@@ -0,0 +1,111 @@
+// Package transport sends requests through a nymeria.Client and decodes
+// their responses for the service packages, including chunked and streaming
+// bulk calls.
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Envelope is the shape of API responses: the payload under "data", plus the
+// status, metadata and total the API sends alongside it.
+type Envelope[T any] struct {
+	Status   int             `json:"status"`
+	Data     T               `json:"data"`
+	MetaData json.RawMessage `json:"metadata,omitempty"`
+	Total    int             `json:"total"`
+}
+
+// Fetch sends a request to endpoint through c and decodes the response
+// envelope. A non-nil body is encoded as JSON. Responses that are a bare JSON
+// array rather than an envelope are decoded into Data. The response body is
+// always drained and closed.
+func Fetch[T any](ctx context.Context, c *nymeria.Client, method, endpoint string, body interface{}) (*Envelope[T], error) {
+	resp, err := Send(ctx, c, method, endpoint, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer Drain(resp)
+
+	var response Envelope[T]
+
+	r := bufio.NewReader(resp.Body)
+
+	if bareArray(r) {
+		response.Status = resp.StatusCode
+		err = json.NewDecoder(r).Decode(&response.Data)
+	} else {
+		err = json.NewDecoder(r).Decode(&response)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Send sends a request to endpoint through c and returns the successful
+// response, whose body the caller must close (see Drain). A non-nil body is
+// encoded as JSON.
+func Send(ctx context.Context, c *nymeria.Client, method, endpoint string, body interface{}) (*http.Response, error) {
+	var data io.Reader
+
+	if body != nil {
+		bs, err := json.Marshal(body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = bytes.NewReader(bs)
+	}
+
+	req, err := c.NewRequestWithContext(ctx, method, endpoint, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.Do(req)
+}
+
+// Drain reads what is left of the response body and closes it so the
+// connection can be reused.
+func Drain(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// bareArray reports whether the next JSON value in r is an array.
+func bareArray(r *bufio.Reader) bool {
+	for {
+		b, err := r.ReadByte()
+
+		if err != nil {
+			return false
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		r.UnreadByte()
+
+		return b == '['
+	}
+}
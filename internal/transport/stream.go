@@ -0,0 +1,219 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Stream sends a request like Fetch but decodes the items of the response's
+// data array one at a time, calling fn with each item's position as soon as
+// it is decoded. Both bare arrays and envelopes are understood. An error
+// returned by fn stops decoding and is returned as is.
+func Stream[T any](ctx context.Context, c *nymeria.Client, method, endpoint string, body interface{}, fn func(i int, item T) error) error {
+	resp, err := Send(ctx, c, method, endpoint, body)
+
+	if err != nil {
+		return err
+	}
+
+	defer Drain(resp)
+
+	dec := json.NewDecoder(resp.Body)
+
+	tok, err := dec.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if tok == json.Delim('[') {
+		return streamItems(dec, fn)
+	}
+
+	if tok != json.Delim('{') {
+		return errors.New("error: unexpected response; expected a json object or array")
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+
+		if err != nil {
+			return err
+		}
+
+		if key != "data" {
+			var skip json.RawMessage
+
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+
+		if tok != json.Delim('[') {
+			return errors.New("error: unexpected response; expected data to be an array")
+		}
+
+		return streamItems(dec, fn)
+	}
+
+	return nil
+}
+
+func streamItems[T any](dec *json.Decoder, fn func(i int, item T) error) error {
+	for i := 0; dec.More(); i++ {
+		var item T
+
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+
+		if err := fn(i, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Batches reads inputs from in, groups them into chunks of the client's bulk
+// chunk size and calls fn for each chunk with at most the client's bulk
+// concurrency in flight. offset is the position of the chunk's first input. A
+// partial chunk is sent once in is closed. The first error returned by fn
+// cancels the remaining chunks and is returned.
+//
+// When Batches returns before in is closed, the inputs still to come are read
+// and discarded in the background until it is, so a producer blocked on a
+// send is never leaked. Producers should still stop early by selecting on
+// ctx.Done() rather than produce inputs nobody will send.
+func Batches[T any](ctx context.Context, c *nymeria.Client, in <-chan T, fn func(ctx context.Context, offset int, batch []T) error) error {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	size, concurrency := c.BulkLimits()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+		sem   = make(chan struct{}, concurrency)
+	)
+
+	dispatch := func(offset int, batch []T) bool {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, offset, batch); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}()
+
+		return true
+	}
+
+	offset := 0
+	batch := make([]T, 0, size)
+
+read:
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				if len(batch) > 0 {
+					dispatch(offset, batch)
+				}
+
+				in = nil
+				break read
+			}
+
+			batch = append(batch, v)
+
+			if len(batch) == size {
+				if !dispatch(offset, batch) {
+					break read
+				}
+
+				offset += len(batch)
+				batch = make([]T, 0, size)
+			}
+		case <-ctx.Done():
+			break read
+		}
+	}
+
+	if in != nil {
+		go func() {
+			for range in {
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if first != nil {
+		return first
+	}
+
+	return parent.Err()
+}
+
+// CallbackError wraps an error returned by a caller's callback so streaming
+// calls can tell it apart from request failures.
+type CallbackError struct {
+	Err error
+}
+
+func (e *CallbackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CallbackError) Unwrap() error {
+	return e.Err
+}
+
+// Emitter serializes calls to a caller's callback and wraps its errors in a
+// *CallbackError. Once the callback has failed it is not called again.
+func Emitter[T any](fn func(T) error) func(T) error {
+	var (
+		mu     sync.Mutex
+		failed error
+	)
+
+	return func(v T) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failed != nil {
+			return failed
+		}
+
+		if err := fn(v); err != nil {
+			failed = &CallbackError{Err: err}
+		}
+
+		return failed
+	}
+}
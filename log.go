@@ -0,0 +1,139 @@
+package nymeria
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Redacted replaces sensitive values in log output.
+const Redacted = "[REDACTED]"
+
+var (
+	// SensitiveHeaders are never logged verbatim.
+	SensitiveHeaders = []string{"X-Api-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+	// SensitiveParams are the query parameters and JSON fields that identify a
+	// person. They are never logged verbatim, and nymeriatest cassettes scrub
+	// them by default.
+	SensitiveParams = []string{
+		"email", "work_email", "personal_emails", "emails", "address",
+		"phone", "mobile_phone", "phone_numbers",
+		"name", "first_name", "last_name", "full_name", "birth_date", "birth_year",
+		"profile", "profiles", "lid",
+		"linkedin_url", "linkedin_username", "linkedin_id",
+		"facebook_url", "facebook_username", "facebook_id",
+		"twitter_url", "twitter_username",
+		"github_url", "github_username",
+	}
+)
+
+// WithLogger logs every request attempt to l at debug level. API keys and
+// personal data (emails, phones, profile URLs, names) are redacted.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) error {
+		c.logger = l
+		return nil
+	}
+}
+
+// logAttempt logs a single request attempt.
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, resp *http.Response, start time.Time, err error) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", c.endpoint(req)),
+		slog.Any("query", RedactQuery(req.URL.Query())),
+		slog.Duration("latency", time.Since(start)),
+		slog.Any("headers", RedactHeaders(req.Header)),
+	}
+
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+		if id := resp.Header.Get("X-Request-Id"); len(id) > 0 {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", RedactError(err)))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "nymeria request", attrs...)
+}
+
+// RedactHeaders returns a copy of h with SensitiveHeaders replaced.
+func RedactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+
+	for _, k := range SensitiveHeaders {
+		if _, ok := h[http.CanonicalHeaderKey(k)]; ok {
+			h.Set(k, Redacted)
+		}
+	}
+
+	return h
+}
+
+// RedactQuery returns a copy of q with SensitiveParams replaced.
+func RedactQuery(q url.Values) url.Values {
+	redacted := url.Values{}
+
+	for k, vs := range q {
+		for _, v := range vs {
+			if sensitiveParam(k) {
+				v = Redacted
+			}
+
+			redacted.Add(k, v)
+		}
+	}
+
+	return redacted
+}
+
+// RedactError returns the message of err with SensitiveParams replaced in the
+// request URL that a *url.Error carries.
+func RedactError(err error) string {
+	var urlErr *url.Error
+
+	if !errors.As(err, &urlErr) {
+		return err.Error()
+	}
+
+	redacted := &url.Error{Op: urlErr.Op, URL: redactURL(urlErr.URL), Err: urlErr.Err}
+
+	return strings.Replace(err.Error(), urlErr.Error(), redacted.Error(), 1)
+}
+
+// redactURL returns rawURL with SensitiveParams replaced in its query. A URL
+// that can't be parsed is dropped entirely.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+
+	if err != nil {
+		return Redacted
+	}
+
+	u.RawQuery = RedactQuery(u.Query()).Encode()
+
+	return u.String()
+}
+
+func sensitiveParam(k string) bool {
+	for _, p := range SensitiveParams {
+		if strings.EqualFold(p, k) {
+			return true
+		}
+	}
+
+	return false
+}
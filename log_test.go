@@ -0,0 +1,94 @@
+package nymeria_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+var private = person.EnrichParams{
+	Email:   "jane.private@example.com",
+	Profile: "linkedin.com/in/jane-private",
+}
+
+// checkRedacted fails t if out contains an API key or a private value, either
+// verbatim or query escaped.
+func checkRedacted(t *testing.T, out, apiKey string) {
+	t.Helper()
+
+	if len(out) == 0 {
+		t.Fatal("nothing was logged")
+	}
+
+	for _, v := range []string{apiKey, private.Email, private.Profile, "jane-private"} {
+		if strings.Contains(out, v) || strings.Contains(out, url.QueryEscape(v)) {
+			t.Errorf("log output contains %q:\n%s", v, out)
+		}
+	}
+
+	if !strings.Contains(out, nymeria.Redacted) {
+		t.Errorf("log output has nothing redacted:\n%s", out)
+	}
+}
+
+func logger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestLogRedactsSuccess(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	srv.APIKey = "secret-key"
+	srv.AddPerson(person.Person{ID: "1", PersonalEmails: []string{private.Email}})
+
+	var buf bytes.Buffer
+
+	if _, err := person.NewService(srv.Client(nymeria.WithLogger(logger(&buf)))).Enrich(private); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRedacted(t, buf.String(), srv.APIKey)
+}
+
+func TestLogRedactsError(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	srv.APIKey = "secret-key"
+
+	var buf bytes.Buffer
+
+	svc := person.NewService(srv.Client(nymeria.WithLogger(logger(&buf))))
+	srv.Close()
+
+	if _, err := svc.Enrich(private); err == nil {
+		t.Fatal("got no error from a closed server")
+	}
+
+	out := buf.String()
+
+	checkRedacted(t, out, srv.APIKey)
+
+	if !strings.Contains(out, `"error"`) {
+		t.Errorf("log output has no error:\n%s", out)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://www.nymeria.io/api/v4/email/verify?email=jane%40example.com", Err: nymeria.ErrServerError}
+
+	got := nymeria.RedactError(err)
+
+	if strings.Contains(got, "jane") || !strings.Contains(got, nymeria.ErrServerError.Error()) || !strings.Contains(got, "/email/verify") {
+		t.Errorf("got %q", got)
+	}
+
+	if got := nymeria.RedactError(nymeria.ErrNotFound); got != nymeria.ErrNotFound.Error() {
+		t.Errorf("got %q for an error without a URL", got)
+	}
+}
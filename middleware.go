@@ -0,0 +1,57 @@
+package nymeria
+
+import (
+	"net/http"
+)
+
+// Middleware wraps the transport used to send each request attempt. It can
+// inspect or modify requests and responses, add tracing, or inject faults.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middleware to the client. The first middleware given is
+// the outermost. Middleware runs once per attempt, so retried requests pass
+// through it again, after rate limiting.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) error {
+		for _, m := range mw {
+			if m == nil {
+				return ErrInvalidParameters
+			}
+		}
+
+		c.middleware = append(c.middleware, mw...)
+		return nil
+	}
+}
+
+// SetHeader returns middleware that sets a header on every request.
+func SetHeader(key, value string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set(key, value)
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// chain wraps base in mw, outermost first.
+func chain(base http.RoundTripper, mw []Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for i := len(mw) - 1; i >= 0; i-- {
+		base = mw[i](base)
+	}
+
+	return base
+}
@@ -0,0 +1,330 @@
+package nymeriatest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// ErrNoInteraction is returned when a replaying Cassette has no recorded
+// interaction matching a request.
+var ErrNoInteraction = errors.New("nymeriatest: no recorded interaction matches request")
+
+// KeyEnv names the environment variable a Cassette reads its Key from when
+// none is set.
+const KeyEnv = "NYMERIATEST_CASSETTE_KEY"
+
+// ErrNoKey is returned for a request with scrubbed values when the Cassette
+// has no Key to hash them with.
+var ErrNoKey = errors.New("nymeriatest: cassette has no key; set Cassette.Key or " + KeyEnv)
+
+// DefaultScrub lists the query parameters and JSON fields whose values are
+// replaced before an interaction is stored. It is nymeria.SensitiveParams, the
+// same personal data the client's logging redacts.
+var DefaultScrub = nymeria.SensitiveParams
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Method   string      `json:"method"`
+	Path     string      `json:"path"`
+	Query    string      `json:"query"` /* normalized; scrubbed values keyed-hashed */
+	Body     string      `json:"body"`  /* normalized; scrubbed values keyed-hashed */
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Response string      `json:"response"` /* scrubbed */
+}
+
+// Cassette is an http.RoundTripper that either records real interactions to a
+// fixture file or replays them from one. Requests match on method, path and
+// the normalized query and body, in which scrubbed values are replaced by
+// their HMAC-SHA256 under Key, so requests stay distinguishable without
+// storing personal data or hashes that a dictionary lookup could reverse. The
+// API key is never stored. Use it as the transport of the client given to
+// nymeria.WithHTTPClient.
+type Cassette struct {
+	Path  string
+	Scrub []string // fields to scrub; DefaultScrub when nil
+
+	// Key is the secret scrubbed request values are hashed with; the value of
+	// KeyEnv when empty. Recording and replaying need the same Key, which must
+	// be kept out of the fixture, e.g. in a CI secret.
+	Key []byte
+
+	next         http.RoundTripper /* nil when replaying */
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a Cassette that sends requests through next (or
+// http.DefaultTransport) and records them. Call Save to write the fixture.
+func NewRecorder(path string, next http.RoundTripper) *Cassette {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Cassette{Path: path, next: next}
+}
+
+// LoadCassette returns a Cassette that replays the interactions saved at path.
+func LoadCassette(path string) (*Cassette, error) {
+	bs, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cassette{Path: path}
+
+	if err := json.Unmarshal(bs, &c.interactions); err != nil {
+		return nil, err
+	}
+
+	c.used = make([]bool, len(c.interactions))
+
+	return c, nil
+}
+
+// Recording reports whether the cassette records rather than replays.
+func (c *Cassette) Recording() bool {
+	return c.next != nil
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to Path.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bs, err := json.MarshalIndent(c.interactions, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.Path, append(bs, '\n'), 0o644)
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		bs, err := io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = bs
+		req.Body = io.NopCloser(bytes.NewReader(bs))
+	}
+
+	hash, missing := c.hasher()
+
+	key := Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  scrubQuery(req.URL.Query(), c.scrub(), hash).Encode(),
+		Body:   c.normalize(body, hash),
+	}
+
+	if *missing {
+		return nil, ErrNoKey
+	}
+
+	if c.Recording() {
+		return c.record(req, key)
+	}
+
+	return c.replay(req, key)
+}
+
+func (c *Cassette) record(req *http.Request, key Interaction) (*http.Response, error) {
+	resp, err := c.next.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(bs))
+
+	key.Status = resp.StatusCode
+	key.Header = resp.Header.Clone()
+	key.Header.Del("Set-Cookie")
+	key.Header.Del("Content-Length") /* the scrubbed body may differ in length */
+	key.Header.Del("Date")
+	key.Response = c.normalize(bs, redact)
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, key)
+	c.used = append(c.used, true)
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay serves the first unused matching interaction, or the last matching
+// one when all have been used.
+func (c *Cassette) replay(req *http.Request, key Interaction) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+
+	for i, in := range c.interactions {
+		if in.Method != key.Method || in.Path != key.Path || in.Query != key.Query || in.Body != key.Body {
+			continue
+		}
+
+		match = i
+
+		if !c.used[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s?%s", ErrNoInteraction, key.Method, key.Path, key.Query)
+	}
+
+	c.used[match] = true
+	in := c.interactions[match]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Response))),
+		ContentLength: int64(len(in.Response)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Cassette) scrub() []string {
+	if c.Scrub == nil {
+		return DefaultScrub
+	}
+
+	return c.Scrub
+}
+
+// normalize scrubs and re-encodes a JSON body so equivalent bodies compare
+// equal, passing scrubbed values through replace. Bodies that aren't JSON are
+// kept as is.
+func (c *Cassette) normalize(bs []byte, replace func(string) string) string {
+	if len(bs) == 0 {
+		return ""
+	}
+
+	var v interface{}
+
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return string(bs)
+	}
+
+	out, _ := json.Marshal(scrubJSON(v, c.scrub(), replace, false))
+
+	return string(out)
+}
+
+// scrubJSON replaces every string below a scrubbed field, keeping the shape of
+// the document so it still decodes into the same types.
+func scrubJSON(v interface{}, fields []string, replace func(string) string, scrubbing bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = scrubJSON(e, fields, replace, scrubbing || hasField(fields, k))
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = scrubJSON(e, fields, replace, scrubbing)
+		}
+	case string:
+		if scrubbing {
+			return replace(v)
+		}
+	}
+
+	return v
+}
+
+func scrubQuery(q url.Values, fields []string, replace func(string) string) url.Values {
+	for k, vs := range q {
+		if hasField(fields, k) {
+			for i := range vs {
+				vs[i] = replace(vs[i])
+			}
+		}
+	}
+
+	return q
+}
+
+// hasher returns the function replacing the scrubbed values of a request,
+// which are part of the replay key, so different values still match different
+// interactions. missing is set when a value was hashed without a key.
+func (c *Cassette) hasher() (hash func(string) string, missing *bool) {
+	key := c.Key
+
+	if len(key) == 0 {
+		key = []byte(os.Getenv(KeyEnv))
+	}
+
+	missing = new(bool)
+
+	return func(v string) string {
+		if len(key) == 0 {
+			*missing = true
+			return nymeria.Redacted
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(v))
+
+		return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+	}, missing
+}
+
+// redact replaces a scrubbed value of a response.
+func redact(string) string {
+	return nymeria.Redacted
+}
+
+func hasField(fields []string, k string) bool {
+	for _, f := range fields {
+		if f == k {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package nymeriatest_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestCassette(t *testing.T) {
+	t.Setenv(nymeriatest.KeyEnv, "")
+
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	alice, bob := "alice@nymeria.io", "bob@nymeria.io"
+	first, last := "Alice", "Liddell"
+	linkedin := "linkedin.com/in/alice"
+
+	srv.AddPerson(
+		person.Person{ID: "alice", WorkEmail: &alice, FirstName: &first, LastName: &last, LinkedinURL: &linkedin},
+		person.Person{ID: "bob", WorkEmail: &bob},
+	)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec := nymeriatest.NewRecorder(path, nil)
+	rec.Key = []byte("cassette secret")
+
+	people := person.NewService(srv.Client(nymeria.WithHTTPClient(&http.Client{Transport: rec})))
+
+	for _, address := range []string{alice, bob} {
+		if _, err := people.Enrich(person.EnrichParams{Email: address}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := people.BulkEnrich(person.BulkEnrichParams{Params: person.EnrichParams{Email: address}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pii := range []string{alice, bob, first, last, linkedin, srv.APIKey} {
+		sum := sha256.Sum256([]byte(pii))
+
+		if len(pii) > 0 && strings.Contains(string(bs), pii) {
+			t.Errorf("cassette contains %q", pii)
+		}
+
+		if strings.Contains(string(bs), hex.EncodeToString(sum[:])) {
+			t.Errorf("cassette contains the unkeyed hash of %q", pii)
+		}
+	}
+
+	cas, err := nymeriatest.LoadCassette(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := replay(srv, cas).Enrich(person.EnrichParams{Email: alice}); !errors.Is(err, nymeriatest.ErrNoKey) {
+		t.Errorf("got %v without a key, want ErrNoKey", err)
+	}
+
+	cas.Key = []byte("another secret")
+
+	if _, err := replay(srv, cas).Enrich(person.EnrichParams{Email: alice}); !errors.Is(err, nymeriatest.ErrNoInteraction) {
+		t.Errorf("got %v with the wrong key, want ErrNoInteraction", err)
+	}
+
+	cas.Key = rec.Key
+	people = replay(srv, cas)
+
+	/* replay in reverse so matching can't rely on order */
+	for _, want := range []string{"bob", "alice"} {
+		p, err := people.Enrich(person.EnrichParams{Email: want + "@nymeria.io"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p.ID != want {
+			t.Errorf("enrich %s: replayed person %q", want, p.ID)
+		}
+
+		results, err := people.BulkEnrich(person.BulkEnrichParams{Params: person.EnrichParams{Email: want + "@nymeria.io"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !results[0].OK() || results[0].Person.ID != want {
+			t.Errorf("bulk enrich %s: replayed %+v", want, results[0])
+		}
+	}
+
+	if _, err := people.Enrich(person.EnrichParams{Email: "carol@nymeria.io"}); !errors.Is(err, nymeriatest.ErrNoInteraction) {
+		t.Errorf("got %v, want ErrNoInteraction", err)
+	}
+}
+
+// replay returns a person service whose requests are served by cas.
+func replay(srv *nymeriatest.Server, cas *nymeriatest.Cassette) *person.Service {
+	c, err := nymeria.NewClient(nymeria.WithBaseURL(srv.BaseURL()), nymeria.WithHTTPClient(&http.Client{Transport: cas}))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return person.NewService(c)
+}
@@ -0,0 +1,86 @@
+package nymeriatest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/company"
+)
+
+func (s *Server) companyEnrich(w http.ResponseWriter, r Request) {
+	q := r.Query
+
+	if len(q.Get("website")) == 0 && len(q.Get("name")) == 0 && len(q.Get("profile")) == 0 && len(q.Get("linkedin_id")) == 0 {
+		writeError(w, http.StatusBadRequest, "website, name, profile or linkedin_id is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.companies {
+		if matchCompanyEnrich(c, q) {
+			writeData(w, c)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "no match found")
+}
+
+func (s *Server) companySearch(w http.ResponseWriter, r Request) {
+	q := r.Query
+
+	s.mu.Lock()
+
+	var matches []company.Company
+
+	for _, c := range s.companies {
+		if matchCompany(c, q) {
+			matches = append(matches, c)
+		}
+	}
+
+	s.mu.Unlock()
+
+	page := paginate(len(matches), q)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":   http.StatusOK,
+		"data":     append([]company.Company{}, matches[page.start:page.end]...),
+		"metadata": page.metadata(),
+		"total":    len(matches),
+	})
+}
+
+// matchCompanyEnrich reports whether c satisfies the company enrich query q.
+func matchCompanyEnrich(c company.Company, q url.Values) bool {
+	if website := q.Get("website"); len(website) > 0 && normalizeURL(c.WebsiteURL) != normalizeURL(website) {
+		return false
+	}
+
+	if name := q.Get("name"); len(name) > 0 && nymeria.Normalize(c.Name) != nymeria.Normalize(name) {
+		return false
+	}
+
+	if profile := q.Get("profile"); len(profile) > 0 && normalizeURL("linkedin.com/company/"+c.LinkedinName) != normalizeURL(profile) {
+		return false
+	}
+
+	if id := q.Get("linkedin_id"); len(id) > 0 && strconv.Itoa(c.LinkedinID) != id {
+		return false
+	}
+
+	return true
+}
+
+// matchCompany reports whether c satisfies the company search query q.
+func matchCompany(c company.Company, q url.Values) bool {
+	return contains(&c.Name, q.Get("name")) &&
+		contains(&c.Location, q.Get("location")) &&
+		contains(&c.Location, q.Get("country")) &&
+		contains(&c.Industry, q.Get("industry")) &&
+		contains(&c.Size, q.Get("size"))
+}
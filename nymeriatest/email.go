@@ -0,0 +1,60 @@
+package nymeriatest
+
+import (
+	"net/http"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+)
+
+func (s *Server) findVerification(address string) (email.Verification, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.verifications[nymeria.Normalize(address)]
+
+	return v, ok
+}
+
+func (s *Server) emailVerify(w http.ResponseWriter, r Request) {
+	address := r.Query.Get("email")
+
+	if len(address) == 0 {
+		writeError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	v, ok := s.findVerification(address)
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "no verification found")
+		return
+	}
+
+	writeData(w, v)
+}
+
+func (s *Server) emailBulkVerify(w http.ResponseWriter, r Request) {
+	var requests []struct {
+		Params struct {
+			Email string `json:"email"`
+		} `json:"params"`
+		MetaData interface{} `json:"metadata"`
+	}
+
+	if !bulkRequest(w, r, &requests) {
+		return
+	}
+
+	items := []item{}
+
+	for _, req := range requests {
+		if v, ok := s.findVerification(req.Params.Email); ok {
+			items = append(items, item{Status: http.StatusOK, MetaData: req.MetaData, Data: v})
+		} else {
+			items = append(items, item{Status: http.StatusNotFound, Message: "no match found", MetaData: req.MetaData})
+		}
+	}
+
+	writeData(w, items)
+}
@@ -0,0 +1,376 @@
+package nymeriatest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/company"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+var (
+	_ person.PersonService   = (*FakePersonService)(nil)
+	_ company.CompanyService = (*FakeCompanyService)(nil)
+	_ email.EmailService     = (*FakeEmailService)(nil)
+)
+
+// FakePersonService is an in-memory person.PersonService backed by seeded
+// people. It matches and pages records the same way Server does, without
+// going through HTTP. Unknown records yield nymeria.ErrNotFound.
+type FakePersonService struct {
+	// Err, when set, is returned by every call.
+	Err error
+
+	mu     sync.Mutex
+	people map[string]person.Person
+}
+
+// NewFakePersonService returns a FakePersonService seeded with people.
+func NewFakePersonService(people ...person.Person) *FakePersonService {
+	f := &FakePersonService{people: map[string]person.Person{}}
+	f.Add(people...)
+
+	return f
+}
+
+// Add seeds people, replacing any with the same ID.
+func (f *FakePersonService) Add(people ...person.Person) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range people {
+		f.people[p.ID] = p
+	}
+}
+
+// sorted returns the seeded people ordered by ID.
+func (f *FakePersonService) sorted() []person.Person {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var people []person.Person
+
+	for _, p := range f.people {
+		people = append(people, p)
+	}
+
+	sort.Slice(people, func(i, j int) bool { return people[i].ID < people[j].ID })
+
+	return people
+}
+
+func (f *FakePersonService) find(params person.EnrichParams) (*person.Person, error) {
+	for _, p := range f.sorted() {
+		if matchEnrich(p, params) {
+			return &p, nil
+		}
+	}
+
+	return nil, nymeria.ErrNotFound
+}
+
+func (f *FakePersonService) EnrichContext(ctx context.Context, params person.EnrichParams) (*person.Person, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return f.find(params)
+}
+
+func (f *FakePersonService) BulkEnrichContext(ctx context.Context, params ...person.BulkEnrichParams) ([]person.BulkEnrichResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if len(params) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	var results []person.BulkEnrichResult
+
+	for i, req := range params {
+		result := person.BulkEnrichResult{Index: i, MetaData: req.MetaData, Status: http.StatusOK}
+
+		if p, err := f.find(req.Params); err == nil {
+			result.Person = p
+		} else {
+			result.Status = http.StatusNotFound
+			result.Err = nymeria.CheckItem(i, http.StatusNotFound, "no match found")
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (f *FakePersonService) PreviewContext(ctx context.Context, params person.PreviewParams) (*person.PersonPreview, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	p, err := f.find(person.EnrichParams(params))
+
+	if err != nil {
+		return nil, err
+	}
+
+	preview := Preview(*p)
+
+	return &preview, nil
+}
+
+func (f *FakePersonService) RetrieveContext(ctx context.Context, id string) (*person.Person, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if len(id) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.people[id]
+
+	if !ok {
+		return nil, nymeria.ErrNotFound
+	}
+
+	return &p, nil
+}
+
+func (f *FakePersonService) BulkRetrieveContext(ctx context.Context, params ...person.BulkRetrieveParams) ([]person.BulkRetrieveResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if len(params) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	var results []person.BulkRetrieveResult
+
+	for i, req := range params {
+		result := person.BulkRetrieveResult{Index: i, ID: req.ID, MetaData: req.MetaData, Status: http.StatusOK}
+
+		if p, err := f.RetrieveContext(ctx, req.ID); err == nil {
+			result.Person = p
+		} else {
+			result.Status = http.StatusNotFound
+			result.Err = nymeria.CheckItem(i, http.StatusNotFound, "no match found")
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (f *FakePersonService) SearchContext(ctx context.Context, params person.SearchParams) (*person.SearchResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	q, _ := url.ParseQuery(params.URL())
+
+	var matches []person.Person
+
+	for _, p := range f.sorted() {
+		if matchPerson(p, q) {
+			matches = append(matches, p)
+		}
+	}
+
+	page := paginate(len(matches), q)
+
+	return &person.SearchResult{
+		Records: matches[page.start:page.end],
+		Total:   len(matches),
+		Offset:  page.offset,
+		Limit:   page.limit,
+	}, nil
+}
+
+// FakeCompanyService is an in-memory company.CompanyService backed by seeded
+// companies.
+type FakeCompanyService struct {
+	// Err, when set, is returned by every call.
+	Err error
+
+	mu        sync.Mutex
+	companies map[string]company.Company
+}
+
+// NewFakeCompanyService returns a FakeCompanyService seeded with companies.
+func NewFakeCompanyService(companies ...company.Company) *FakeCompanyService {
+	f := &FakeCompanyService{companies: map[string]company.Company{}}
+	f.Add(companies...)
+
+	return f
+}
+
+// Add seeds companies, replacing any with the same ID.
+func (f *FakeCompanyService) Add(companies ...company.Company) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, c := range companies {
+		f.companies[c.ID] = c
+	}
+}
+
+func (f *FakeCompanyService) sorted() []company.Company {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var companies []company.Company
+
+	for _, c := range f.companies {
+		companies = append(companies, c)
+	}
+
+	sort.Slice(companies, func(i, j int) bool { return companies[i].ID < companies[j].ID })
+
+	return companies
+}
+
+func (f *FakeCompanyService) EnrichContext(ctx context.Context, params company.EnrichParams) (*company.Company, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	q, _ := url.ParseQuery(params.URL())
+
+	for _, c := range f.sorted() {
+		if matchCompanyEnrich(c, q) {
+			return &c, nil
+		}
+	}
+
+	return nil, nymeria.ErrNotFound
+}
+
+func (f *FakeCompanyService) SearchContext(ctx context.Context, params company.SearchParams) (*company.SearchResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	q, _ := url.ParseQuery(params.URL())
+
+	var matches []company.Company
+
+	for _, c := range f.sorted() {
+		if matchCompany(c, q) {
+			matches = append(matches, c)
+		}
+	}
+
+	page := paginate(len(matches), q)
+
+	return &company.SearchResult{
+		Records: matches[page.start:page.end],
+		Total:   len(matches),
+		Offset:  page.offset,
+		Limit:   page.limit,
+	}, nil
+}
+
+// FakeEmailService is an in-memory email.EmailService backed by seeded
+// verifications.
+type FakeEmailService struct {
+	// Err, when set, is returned by every call.
+	Err error
+
+	mu            sync.Mutex
+	verifications map[string]email.Verification
+}
+
+// NewFakeEmailService returns an empty FakeEmailService.
+func NewFakeEmailService() *FakeEmailService {
+	return &FakeEmailService{verifications: map[string]email.Verification{}}
+}
+
+// Add seeds the verification returned for address.
+func (f *FakeEmailService) Add(address string, v email.Verification) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.verifications[nymeria.Normalize(address)] = v
+}
+
+func (f *FakeEmailService) VerifyContext(ctx context.Context, address string) (*email.Verification, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	address = nymeria.Normalize(address)
+
+	if len(address) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	v, ok := f.verifications[address]
+
+	if !ok {
+		return nil, nymeria.ErrNotFound
+	}
+
+	return &v, nil
+}
+
+func (f *FakeEmailService) BulkVerifyContext(ctx context.Context, params ...email.BulkVerifyParams) ([]email.BulkVerifyResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if len(params) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
+
+	var results []email.BulkVerifyResult
+
+	for i, p := range params {
+		result := email.BulkVerifyResult{Index: i, Email: nymeria.Normalize(p.Email), MetaData: p.MetaData, Status: http.StatusOK}
+
+		if v, err := f.VerifyContext(ctx, p.Email); err == nil {
+			result.Verification = v
+		} else {
+			result.Status = http.StatusNotFound
+			result.Err = nymeria.CheckItem(i, http.StatusNotFound, "no verification found")
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,164 @@
+package nymeriatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// Fault scripts a misbehaviour of the server. A fault applies to requests for
+// Path (every path when empty) and, when Call is set, only to the Call-th
+// request to that path, counting from 1. The first matching fault wins.
+type Fault struct {
+	Path string
+	Call int
+
+	Latency time.Duration // delay before answering
+
+	Status int         // answer with this status instead of the real response
+	Header http.Header // headers added to the answer, e.g. Retry-After
+	Body   string      // body sent with Status; defaults to an error envelope
+
+	Truncate      int  // cut the real response body after this many bytes
+	MalformedJSON bool // replace the real response body with invalid JSON
+
+	FailItems  []int // indexes of bulk items answered with ItemStatus instead
+	ItemStatus int   // status of failed bulk items (default: 500)
+}
+
+// Inject adds faults to the server.
+func (s *Server) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, faults...)
+}
+
+// ClearFaults removes every injected fault and resets the call counters.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+	s.calls = nil
+}
+
+// fault counts a call to path and returns the fault that applies to it.
+func (s *Server) fault(path string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.calls == nil {
+		s.calls = map[string]int{}
+	}
+
+	s.calls[path]++
+	n := s.calls[path]
+
+	for _, f := range s.faults {
+		if (len(f.Path) == 0 || f.Path == path) && (f.Call == 0 || f.Call == n) {
+			return f, true
+		}
+	}
+
+	return Fault{}, false
+}
+
+// serveFault answers r according to f.
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, req Request, f Fault) {
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		defer t.Stop()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-t.C:
+		}
+	}
+
+	for k, vs := range f.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+
+	if f.Status != 0 {
+		if len(f.Body) > 0 {
+			w.WriteHeader(f.Status)
+			w.Write([]byte(f.Body))
+		} else {
+			writeError(w, f.Status, http.StatusText(f.Status))
+		}
+
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	s.route(rec, req)
+
+	body := rec.Body.Bytes()
+
+	if len(f.FailItems) > 0 {
+		body = failItems(body, f.FailItems, f.ItemStatus)
+	}
+
+	if f.MalformedJSON {
+		body = []byte(`{"status": 200, "data": [{`)
+	}
+
+	if f.Truncate > 0 && f.Truncate < len(body) {
+		body = body[:f.Truncate]
+	}
+
+	for k, vs := range rec.Header() {
+		w.Header()[k] = vs
+	}
+
+	w.WriteHeader(rec.Code)
+	w.Write(body)
+}
+
+// failItems rewrites the given items of a bulk response, whether it is a bare
+// array or a {"data": [...]} envelope, to carry status instead of data.
+func failItems(body []byte, indexes []int, status int) []byte {
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	var items []map[string]interface{}
+	var envelope map[string]interface{}
+
+	if err := json.Unmarshal(body, &items); err != nil {
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return body
+		}
+
+		bs, _ := json.Marshal(envelope["data"])
+
+		if err := json.Unmarshal(bs, &items); err != nil {
+			return body
+		}
+	}
+
+	for _, i := range indexes {
+		if i >= 0 && i < len(items) {
+			items[i]["status"] = status
+			delete(items[i], "data")
+		}
+	}
+
+	var out interface{} = items
+
+	if envelope != nil {
+		envelope["data"] = items
+		out = envelope
+	}
+
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(out)
+
+	return buf.Bytes()
+}
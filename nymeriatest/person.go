@@ -0,0 +1,396 @@
+package nymeriatest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+// findPerson returns the seeded person matching the enrich parameters.
+func (s *Server) findPerson(params person.EnrichParams) (person.Person, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.people {
+		if matchEnrich(p, params) {
+			return p, true
+		}
+	}
+
+	return person.Person{}, false
+}
+
+// matchEnrich reports whether p is identified by the enrich parameters.
+func matchEnrich(p person.Person, params person.EnrichParams) bool {
+	if len(params.Email) > 0 && hasEmail(p, params.Email) {
+		return true
+	}
+
+	if len(params.Profile) > 0 && hasProfile(p, params.Profile) {
+		return true
+	}
+
+	return len(params.LID) > 0 && p.LinkedinID != nil && *p.LinkedinID == params.LID
+}
+
+func (s *Server) findPersonByID(id string) (person.Person, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.people {
+		if p.ID == id {
+			return p, true
+		}
+	}
+
+	return person.Person{}, false
+}
+
+func hasEmail(p person.Person, address string) bool {
+	address = nymeria.Normalize(address)
+
+	if p.WorkEmail != nil && nymeria.Normalize(*p.WorkEmail) == address {
+		return true
+	}
+
+	for _, e := range p.PersonalEmails {
+		if nymeria.Normalize(e) == address {
+			return true
+		}
+	}
+
+	for _, e := range p.Emails {
+		if nymeria.Normalize(e.Full) == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasProfile(p person.Person, profile string) bool {
+	profile = normalizeURL(profile)
+
+	for _, u := range []*string{p.LinkedinURL, p.FacebookURL, p.TwitterURL, p.GithubURL} {
+		if u != nil && normalizeURL(*u) == profile {
+			return true
+		}
+	}
+
+	for _, l := range p.Profiles {
+		if normalizeURL(l.URL) == profile {
+			return true
+		}
+	}
+
+	return false
+}
+
+func enrichParams(r Request) person.EnrichParams {
+	return person.EnrichParams{
+		Profile: r.Query.Get("profile"),
+		Email:   r.Query.Get("email"),
+		LID:     r.Query.Get("lid"),
+		Filter:  person.Filter(r.Query.Get("filter")),
+		Require: person.Requirement(r.Query.Get("require")),
+	}
+}
+
+func (s *Server) personEnrich(w http.ResponseWriter, r Request) {
+	params := enrichParams(r)
+
+	if params.Invalid() {
+		writeError(w, http.StatusBadRequest, "profile, email or lid is required")
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, ok := s.findPerson(params)
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "no match found")
+		return
+	}
+
+	writeData(w, p)
+}
+
+func (s *Server) personBulkEnrich(w http.ResponseWriter, r Request) {
+	var requests []person.BulkEnrichParams
+
+	if !bulkRequest(w, r, &requests) {
+		return
+	}
+
+	items := []item{}
+
+	for _, req := range requests {
+		if p, ok := s.findPerson(req.Params); ok {
+			items = append(items, item{Status: http.StatusOK, MetaData: req.MetaData, Data: p})
+		} else {
+			items = append(items, item{Status: http.StatusNotFound, Message: "no match found", MetaData: req.MetaData})
+		}
+	}
+
+	writeData(w, items)
+}
+
+func (s *Server) personPreview(w http.ResponseWriter, r Request) {
+	params := enrichParams(r)
+
+	if params.Invalid() {
+		writeError(w, http.StatusBadRequest, "profile, email or lid is required")
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, ok := s.findPerson(params)
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "no match found")
+		return
+	}
+
+	writeData(w, Preview(p))
+}
+
+func (s *Server) personRetrieve(w http.ResponseWriter, r Request) {
+	p, ok := s.findPersonByID(strings.TrimPrefix(r.Path, "/person/retrieve/"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "no match found")
+		return
+	}
+
+	writeData(w, p)
+}
+
+func (s *Server) personBulkRetrieve(w http.ResponseWriter, r Request) {
+	var requests []person.BulkRetrieveParams
+
+	if !bulkRequest(w, r, &requests) {
+		return
+	}
+
+	items := []item{}
+
+	for _, req := range requests {
+		if p, ok := s.findPersonByID(req.ID); ok {
+			items = append(items, item{Status: http.StatusOK, MetaData: req.MetaData, Data: p})
+		} else {
+			items = append(items, item{Status: http.StatusNotFound, Message: "no match found", MetaData: req.MetaData})
+		}
+	}
+
+	writeData(w, items)
+}
+
+func (s *Server) personSearch(w http.ResponseWriter, r Request) {
+	q := r.Query
+
+	s.mu.Lock()
+
+	var matches []person.Person
+
+	for _, p := range s.people {
+		if matchPerson(p, q) {
+			matches = append(matches, p)
+		}
+	}
+
+	s.mu.Unlock()
+
+	page := paginate(len(matches), q)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":   http.StatusOK,
+		"data":     append([]person.Person{}, matches[page.start:page.end]...),
+		"metadata": page.metadata(),
+		"total":    len(matches),
+	})
+}
+
+// matchPerson reports whether p satisfies the person search query q.
+func matchPerson(p person.Person, q url.Values) bool {
+	if !(contains(p.FirstName, q.Get("first_name")) &&
+		contains(p.LastName, q.Get("last_name")) &&
+		contains(p.JobTitle, q.Get("title")) &&
+		contains(p.JobCompanyName, q.Get("company")) &&
+		contains(p.LocationCountry, q.Get("country")) &&
+		contains(p.LocationName, q.Get("location")) &&
+		contains(p.Industry, q.Get("industry"))) {
+		return false
+	}
+
+	if !anyOf(q["skills"], p.Skills...) || !anyOf(q["job_title_levels"], p.JobTitleLevels...) {
+		return false
+	}
+
+	if !anyOf(q["job_title_role"], deref(p.JobTitleRole)...) || !anyOf(q["job_company_size"], deref(p.JobCompanySize)...) {
+		return false
+	}
+
+	if least, _ := strconv.Atoi(q.Get("min_experience")); least > 0 && (p.InferredExperience == nil || *p.InferredExperience < least) {
+		return false
+	}
+
+	if most, _ := strconv.Atoi(q.Get("max_experience")); most > 0 && (p.InferredExperience == nil || *p.InferredExperience > most) {
+		return false
+	}
+
+	if q.Get("has_email") == "true" && p.WorkEmail == nil && len(p.PersonalEmails) == 0 && len(p.Emails) == 0 {
+		return false
+	}
+
+	if q.Get("has_phone") == "true" && p.MobilePhone == nil && len(p.PhoneNumbers) == 0 {
+		return false
+	}
+
+	return true
+}
+
+// anyOf reports whether any wanted value equals one of have, ignoring case.
+// Nothing wanted always matches.
+func anyOf(wanted []string, have ...string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+
+	for _, w := range wanted {
+		for _, h := range have {
+			if nymeria.Normalize(w) == nymeria.Normalize(h) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func deref(s *string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return []string{*s}
+}
+
+// Preview returns the preview the fake server answers with for p.
+func Preview(p person.Person) person.PersonPreview {
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+
+		return *s
+	}
+
+	return person.PersonPreview{
+		ID:             p.ID,
+		FirstName:      str(p.FirstName),
+		LastName:       str(p.LastName),
+		FullName:       str(p.FullName),
+		JobTitle:       str(p.JobTitle),
+		LocationName:   str(p.LocationName),
+		JobCompanyName: str(p.JobCompanyName),
+
+		Gender:                p.Gender != nil,
+		Age:                   p.Age != nil,
+		BirthYear:             p.BirthYear != nil,
+		BirthDate:             p.BirthDate != nil,
+		WorkEmail:             p.WorkEmail != nil,
+		PersonalEmails:        len(p.PersonalEmails) > 0,
+		Emails:                len(p.Emails) > 0,
+		MobilePhone:           p.MobilePhone != nil,
+		PhoneNumbers:          len(p.PhoneNumbers) > 0,
+		Industry:              p.Industry != nil,
+		LocationLastUpdated:   p.LocationLastUpdated != nil,
+		LocationCountry:       p.LocationCountry != nil,
+		InferredExperience:    p.InferredExperience != nil,
+		InferredSalary:        p.InferredSalary != nil,
+		JobTitleRole:          p.JobTitleRole != nil,
+		JobTitleLevels:        len(p.JobTitleLevels) > 0,
+		JobStartDate:          p.JobStartDate != nil,
+		JobCompanyURL:         p.JobCompanyURL != nil,
+		JobCompanyFounded:     p.JobCompanyFounded != nil,
+		JobCompanySize:        p.JobCompanySize != nil,
+		JobCompanyLinkedinURL: p.JobCompanyLinkedinURL != nil,
+		JobLastUpdated:        p.JobLastUpdated != nil,
+		JobSummary:            p.JobSummary != nil,
+		Skills:                len(p.Skills) > 0,
+		Interests:             len(p.Interests) > 0,
+		LinkedinUsername:      p.LinkedinUsername != nil,
+		LinkedinURL:           p.LinkedinURL != nil,
+		LinkedinID:            p.LinkedinID != nil,
+		LinkedinConnections:   p.LinkedinConnections != nil,
+		FacebookUsername:      p.FacebookUsername != nil,
+		FacebookURL:           p.FacebookURL != nil,
+		FacebookID:            p.FacebookID != nil,
+		TwitterUsername:       p.TwitterUsername != nil,
+		TwitterURL:            p.TwitterURL != nil,
+		GithubUsername:        p.GithubUsername != nil,
+		GithubURL:             p.GithubURL != nil,
+		Profiles:              len(p.Profiles) > 0,
+		LinkedinSummary:       p.LinkedinSummary != nil,
+		Education:             len(p.Education) > 0,
+		Experience:            len(p.Experience) > 0,
+		Certificates:          len(p.Certificates) > 0,
+		Languages:             len(p.Languages) > 0,
+	}
+}
+
+// page is the slice of a result set selected by limit and offset.
+type page struct {
+	start, end, limit, offset int
+}
+
+// paginate applies the limit and offset query parameters the way the API
+// does: limit defaults to 10 and is capped at 100.
+func paginate(total int, q url.Values) page {
+	get := func(k string) int {
+		n, _ := strconv.Atoi(q.Get(k))
+		return n
+	}
+
+	p := page{limit: get("limit"), offset: get("offset")}
+
+	if p.limit <= 0 {
+		p.limit = 10
+	}
+
+	if p.limit > 100 {
+		p.limit = 100
+	}
+
+	if p.offset < 0 {
+		p.offset = 0
+	}
+
+	p.start, p.end = p.offset, p.offset+p.limit
+
+	if p.start > total {
+		p.start = total
+	}
+
+	if p.end > total {
+		p.end = total
+	}
+
+	return p
+}
+
+func (p page) metadata() map[string]int {
+	return map[string]int{"limit": p.limit, "offset": p.offset}
+}
@@ -0,0 +1,274 @@
+// Package nymeriatest provides an in-process fake of the Nymeria API for tests.
+//
+// A Server speaks the same envelopes as the real API, serves records seeded by
+// the test and records every request it receives:
+//
+//	srv := nymeriatest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddPerson(person.Person{ID: "p1", WorkEmail: &email})
+//	people := person.NewService(srv.Client())
+package nymeriatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/company"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+// Prefix is the path the fake API is served under.
+const Prefix = "/api/v" + nymeria.ApiVersion
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string // relative to Prefix, e.g. "/person/enrich"
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Nymeria API backed by seeded records. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, when set, must be sent by every request or the server answers
+	// 401.
+	APIKey string
+
+	mu            sync.Mutex
+	people        []person.Person
+	companies     []company.Company
+	verifications map[string]email.Verification
+	requests      []Request
+	faults        []Fault
+	calls         map[string]int
+}
+
+// NewServer starts a Server. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{verifications: map[string]email.Verification{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// BaseURL returns the URL to pass to nymeria.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + Prefix
+}
+
+// Client returns a nymeria.Client that talks to the server. Options are
+// applied after the base URL and API key, so they can override them.
+func (s *Server) Client(opts ...nymeria.Option) *nymeria.Client {
+	key := s.APIKey
+
+	if len(key) == 0 {
+		key = "nymeriatest"
+	}
+
+	opts = append([]nymeria.Option{nymeria.WithBaseURL(s.BaseURL()), nymeria.WithAPIKey(key)}, opts...)
+
+	c, err := nymeria.NewClient(opts...)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// AddPerson seeds people returned by the person endpoints.
+func (s *Server) AddPerson(people ...person.Person) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.people = append(s.people, people...)
+}
+
+// AddCompany seeds companies returned by the company endpoints.
+func (s *Server) AddCompany(companies ...company.Company) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.companies = append(s.companies, companies...)
+}
+
+// AddVerification seeds the verification returned for address. Addresses that
+// were not seeded are answered with 404.
+func (s *Server) AddVerification(address string, v email.Verification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.verifications[nymeria.Normalize(address)] = v
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for path, such as "/person/enrich".
+func (s *Server) RequestsTo(path string) []Request {
+	var rs []Request
+
+	for _, r := range s.Requests() {
+		if r.Path == path {
+			rs = append(rs, r)
+		}
+	}
+
+	return rs
+}
+
+// Reset forgets the recorded requests. Seeded records are kept.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	req := Request{
+		Method: r.Method,
+		Path:   strings.TrimPrefix(r.URL.Path, Prefix),
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	if len(s.APIKey) > 0 && r.Header.Get("X-Api-Key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid api key")
+		return
+	}
+
+	if f, ok := s.fault(req.Path); ok {
+		s.serveFault(w, r, req, f)
+		return
+	}
+
+	s.route(w, req)
+}
+
+func (s *Server) route(w http.ResponseWriter, r Request) {
+	method := http.MethodGet
+
+	if strings.HasSuffix(r.Path, "/bulk") {
+		method = http.MethodPost
+	}
+
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	switch {
+	case r.Path == "/person/enrich":
+		s.personEnrich(w, r)
+	case r.Path == "/person/enrich/bulk":
+		s.personBulkEnrich(w, r)
+	case r.Path == "/person/enrich/preview":
+		s.personPreview(w, r)
+	case r.Path == "/person/retrieve/bulk":
+		s.personBulkRetrieve(w, r)
+	case strings.HasPrefix(r.Path, "/person/retrieve/"):
+		s.personRetrieve(w, r)
+	case r.Path == "/person/search":
+		s.personSearch(w, r)
+	case r.Path == "/company/enrich":
+		s.companyEnrich(w, r)
+	case r.Path == "/company/search":
+		s.companySearch(w, r)
+	case r.Path == "/email/verify":
+		s.emailVerify(w, r)
+	case r.Path == "/email/verify/bulk":
+		s.emailBulkVerify(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+	}
+}
+
+// item is one entry of a bulk response.
+type item struct {
+	Status   int         `json:"status"`
+	Message  string      `json:"message,omitempty"`
+	MetaData interface{} `json:"metadata,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// bulkRequest decodes the {"requests": [...]} body shared by bulk endpoints.
+func bulkRequest(w http.ResponseWriter, r Request, v interface{}) bool {
+	if err := json.Unmarshal(r.Body, &struct {
+		Requests interface{} `json:"requests"`
+	}{v}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body")
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+
+	json.NewEncoder(&buf).Encode(v)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": http.StatusOK,
+		"data":   data,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"status":  status,
+		"message": message,
+	})
+}
+
+// contains reports whether s contains substr, ignoring case. An empty substr
+// always matches.
+func contains(s *string, substr string) bool {
+	if len(substr) == 0 {
+		return true
+	}
+
+	return s != nil && strings.Contains(nymeria.Normalize(*s), nymeria.Normalize(substr))
+}
+
+// normalizeURL strips the scheme, "www." and trailing slashes so URLs given in
+// different forms compare equal.
+func normalizeURL(u string) string {
+	u = nymeria.Normalize(u)
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	u = strings.TrimPrefix(u, "www.")
+
+	return strings.TrimSuffix(u, "/")
+}
@@ -0,0 +1,142 @@
+package nymeria
+
+import (
+	"context"
+)
+
+// PageFunc fetches the page of up to limit records starting at offset. total
+// is the number of matching records reported by the API, or 0 if unknown.
+type PageFunc[T any] func(ctx context.Context, offset, limit int) (records []T, total int, err error)
+
+// Pager walks every page of a paginated endpoint one record at a time,
+// de-duplicating records that appear on more than one page. The search
+// iterators of person and company are built on it.
+type Pager[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	key   func(T) string
+
+	offset int
+	limit  int
+	max    int
+
+	page    []T
+	pos     int
+	total   int
+	last    int /* the limit of the last request */
+	fetched bool
+	done    bool
+	count   int
+	seen    map[string]bool
+	current T
+	err     error
+}
+
+// NewPager returns a Pager that starts at offset, requests pages of limit
+// records and stops after max records (no cap when max <= 0). No page asks for
+// more records than are still needed to reach max. key identifies a record for
+// de-duplication; records with an empty key are never skipped.
+func NewPager[T any](ctx context.Context, fetch PageFunc[T], key func(T) string, offset, limit, max int) *Pager[T] {
+	return &Pager[T]{ctx: ctx, fetch: fetch, key: key, offset: offset, limit: limit, max: max, seen: map[string]bool{}}
+}
+
+// Next advances to the next record, fetching the next page when needed. It
+// returns false when the results or the cap are exhausted, the context is
+// done, or a request failed; check Err afterwards.
+func (p *Pager[T]) Next() bool {
+	for !p.done {
+		if p.max > 0 && p.count >= p.max {
+			p.done = true
+			break
+		}
+
+		if p.pos >= len(p.page) {
+			if err := p.next(); err != nil {
+				p.err = err
+				p.done = true
+			}
+
+			continue
+		}
+
+		r := p.page[p.pos]
+		p.pos++
+
+		if k := p.key(r); len(k) > 0 {
+			if p.seen[k] {
+				continue
+			}
+
+			p.seen[k] = true
+		}
+
+		p.current = r
+		p.count++
+
+		return true
+	}
+
+	return false
+}
+
+// next loads the next page, marking the pager done at the end of results.
+func (p *Pager[T]) next() error {
+	if p.fetched && (len(p.page) < p.last || (p.total > 0 && p.offset >= p.total)) {
+		p.done = true
+		return nil
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+
+	/* never pay for more records than the cap leaves room for */
+	limit := p.limit
+
+	if p.max > 0 {
+		limit = min(limit, p.max-p.count)
+	}
+
+	records, total, err := p.fetch(p.ctx, p.offset, limit)
+
+	if err != nil {
+		return err
+	}
+
+	p.page, p.pos, p.total, p.fetched, p.last = records, 0, total, true, limit
+	p.offset += len(records)
+
+	if len(records) == 0 {
+		p.done = true
+	}
+
+	return nil
+}
+
+// Value returns the current record.
+func (p *Pager[T]) Value() T {
+	return p.current
+}
+
+// Total returns the number of matching records reported by the API. It is 0
+// until the first page has been fetched.
+func (p *Pager[T]) Total() int {
+	return p.total
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All collects the remaining records. On error the records collected so far
+// are returned alongside it.
+func (p *Pager[T]) All() ([]T, error) {
+	var records []T
+
+	for p.Next() {
+		records = append(records, p.Value())
+	}
+
+	return records, p.Err()
+}
@@ -1,15 +1,13 @@
 package person
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
 type BulkEnrichParams struct {
@@ -17,18 +15,43 @@ type BulkEnrichParams struct {
 	MetaData interface{}  `json:"metadata"`
 }
 
+// BulkEnrichResult is the outcome of one BulkEnrichParams. Results are
+// returned in input order.
+type BulkEnrichResult struct {
+	Index    int         // position of the input in the request
+	MetaData interface{} // the input's metadata
+	Status   int         // the item's status, e.g. 200 or 404
+	Err      error       // nil on success; an *nymeria.ItemError otherwise
+	Person   *Person     // set on success
+}
+
+// OK reports whether the item was enriched.
+func (r BulkEnrichResult) OK() bool {
+	return r.Err == nil && r.Person != nil
+}
+
 type EnrichParams struct {
-	Profile string `json:"profile,omitempty"`
-	Email   string `json:"email,omitempty"`
-	LID     string `json:"lid,omitempty"`
-	Filter  string `json:"filter,omitempty"`
-	Require string `json:"require,omitempty"`
+	Profile string      `json:"profile,omitempty"`
+	Email   string      `json:"email,omitempty"`
+	LID     string      `json:"lid,omitempty"`
+	Filter  Filter      `json:"filter,omitempty"`
+	Require Requirement `json:"require,omitempty"`
 }
 
 func (e EnrichParams) Invalid() bool {
 	return len(e.Profile) == 0 && len(e.Email) == 0 && len(e.LID) == 0
 }
 
+// Validate reports an error wrapping nymeria.ErrInvalidParameters if no
+// profile, email or LID is given, or if Filter or Require is unknown.
+func (e EnrichParams) Validate() error {
+	if e.Invalid() {
+		return nymeria.ErrInvalidParameters
+	}
+
+	return validateOptions(e.Filter, e.Require)
+}
+
 func (e EnrichParams) URL() string {
 	var query strings.Builder
 
@@ -50,12 +73,12 @@ func (e EnrichParams) URL() string {
 	}
 
 	if len(e.Filter) > 0 {
-		query.WriteString(fmt.Sprintf("%sfilter=%s", prefix, url.QueryEscape(e.Filter)))
+		query.WriteString(fmt.Sprintf("%sfilter=%s", prefix, url.QueryEscape(string(e.Filter))))
 		prefix = "&"
 	}
 
 	if len(e.Require) > 0 {
-		query.WriteString(fmt.Sprintf("%srequire=%s", prefix, url.QueryEscape(e.Require)))
+		query.WriteString(fmt.Sprintf("%srequire=%s", prefix, url.QueryEscape(string(e.Require))))
 		prefix = "&"
 	}
 
@@ -63,109 +86,160 @@ func (e EnrichParams) URL() string {
 }
 
 func Enrich(params EnrichParams) (*Person, error) {
-	if params.Invalid() {
-		return nil, nymeria.ErrInvalidParameters
-	}
+	return std().Enrich(params)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/person/enrich?%s", params.URL()), nil)
+func EnrichContext(ctx context.Context, params EnrichParams) (*Person, error) {
+	return std().EnrichContext(ctx, params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) Enrich(params EnrichParams) (*Person, error) {
+	return s.EnrichContext(context.Background(), params)
+}
 
-	resp, err := nymeria.Client.Do(req)
+func (s *Service) EnrichContext(ctx context.Context, params EnrichParams) (*Person, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.Enrich", Endpoint: "/person/enrich"})
+
+	record, err := s.enrich(ctx, params)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
-
-		return nil, nymeria.ErrServerError
-	}
-
-	defer resp.Body.Close()
+	call.End(1, nil)
 
-	bs, err := io.ReadAll(resp.Body)
+	return record, nil
+}
 
-	if err != nil {
+func (s *Service) enrich(ctx context.Context, params EnrichParams) (*Person, error) {
+	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 
-	var response struct {
-		Status int    `json:"status"`
-		Data   Person `json:"data"`
-	}
+	response, err := transport.Fetch[Person](ctx, s.client, "GET", fmt.Sprintf("/person/enrich?%s", params.URL()), nil)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
 	return &response.Data, nil
 }
 
-func BulkEnrich(params ...BulkEnrichParams) ([]Person, error) {
+func BulkEnrich(params ...BulkEnrichParams) ([]BulkEnrichResult, error) {
+	return std().BulkEnrich(params...)
+}
+
+func BulkEnrichContext(ctx context.Context, params ...BulkEnrichParams) ([]BulkEnrichResult, error) {
+	return std().BulkEnrichContext(ctx, params...)
+}
+
+func (s *Service) BulkEnrich(params ...BulkEnrichParams) ([]BulkEnrichResult, error) {
+	return s.BulkEnrichContext(context.Background(), params...)
+}
+
+func (s *Service) BulkEnrichContext(ctx context.Context, params ...BulkEnrichParams) ([]BulkEnrichResult, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.BulkEnrich", Endpoint: "/person/enrich/bulk", BulkSize: len(params)})
+
+	results, err := s.bulkEnrich(ctx, params...)
+	call.End(transport.Succeeded(results), err)
+
+	return results, err
+}
+
+func (s *Service) bulkEnrich(ctx context.Context, params ...BulkEnrichParams) ([]BulkEnrichResult, error) {
 	if len(params) == 0 {
 		return nil, nymeria.ErrInvalidParameters
 	}
 
-	bs, err := json.Marshal(map[string]interface{}{
-		"requests": params,
-	})
-
-	if err != nil {
+	if err := validateBulk(0, params); err != nil {
 		return nil, err
 	}
 
-	req, err := nymeria.Request("POST", "/person/enrich/bulk", bytes.NewBuffer(bs))
+	return bulkEnrichCall.Send(ctx, s.client, params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// bulkEnrichCall describes the bulk enrich endpoint.
+var bulkEnrichCall = transport.Bulk[BulkEnrichParams, bulkItem, BulkEnrichResult]{
+	Endpoint: "/person/enrich/bulk",
+	Body: func(offset int, batch []BulkEnrichParams) (interface{}, error) {
+		if err := validateBulk(offset, batch); err != nil {
+			return nil, err
+		}
 
-	req.Header.Add("Content-Type", "application/json")
+		return map[string]interface{}{"requests": batch}, nil
+	},
+	Correlate: correlateEnrich,
+	Result:    enrichResult,
+	Fail: func(index int, p BulkEnrichParams, err error) BulkEnrichResult {
+		return BulkEnrichResult{Index: index, MetaData: p.MetaData, Err: err}
+	},
+}
 
-	resp, err := nymeria.Client.Do(req)
+// correlateEnrich matches bulk enrich items to params by their echoed
+// metadata, so a reordered response can't attach one input's person to
+// another. Items echoing no metadata fall back to their position.
+func correlateEnrich(params []BulkEnrichParams) *transport.Correlator[bulkItem] {
+	keys := make([]string, len(params))
 
-	if err != nil {
-		return nil, err
+	for i, p := range params {
+		keys[i] = transport.MetadataKey(p.MetaData)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	return transport.NewCorrelator(keys, func(v bulkItem) string {
+		return transport.MetadataKey(v.MetaData)
+	})
+}
 
-		return nil, nymeria.ErrServerError
+// validateBulk checks the filter and requirement of every input so that no
+// credits are spent on a chunk the API would reject. offset is the position of
+// the first input.
+func validateBulk(offset int, params []BulkEnrichParams) error {
+	for i, p := range params {
+		if err := validateOptions(p.Params.Filter, p.Params.Require); err != nil {
+			return fmt.Errorf("request %d: %w", offset+i, err)
+		}
 	}
 
-	defer resp.Body.Close()
+	return nil
+}
 
-	bs, err = io.ReadAll(resp.Body)
+// enrichResult builds the result of input p at index from its response item,
+// which is nil when the API returned none.
+func enrichResult(index int, p BulkEnrichParams, v *bulkItem) BulkEnrichResult {
+	result := BulkEnrichResult{Index: index, MetaData: p.MetaData}
 
-	if err != nil {
-		return nil, err
+	if v == nil {
+		result.Err = nymeria.CheckItem(index, 0, "")
+		return result
 	}
 
-	var response []struct {
-		Status int    `json:"status"`
-		Data   Person `json:"data"`
-	}
+	result.Status = v.Status
+	result.Err = nymeria.CheckItem(index, v.Status, v.Message)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
+	if result.Err == nil {
+		result.Person = v.Data
 	}
 
-	var records []Person
+	return result
+}
 
-	for _, v := range response {
-		if v.Status == 200 {
-			records = append(records, v.Data)
-		}
-	}
+func BulkEnrichStream(ctx context.Context, in <-chan BulkEnrichParams, fn func(BulkEnrichResult) error) error {
+	return std().BulkEnrichStream(ctx, in, fn)
+}
+
+// BulkEnrichStream enriches every input received from in, in chunks, and
+// calls fn with each result as soon as it is decoded, so results don't have to
+// be held in memory. Results arrive in completion order; use Index to place
+// them. fn is never called concurrently. Failed chunks are reported as
+// results carrying the chunk's error and returned as a *nymeria.BulkError once
+// the stream is done; an error returned by fn stops the stream and is
+// returned.
+func (s *Service) BulkEnrichStream(ctx context.Context, in <-chan BulkEnrichParams, fn func(BulkEnrichResult) error) error {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.BulkEnrichStream", Endpoint: "/person/enrich/bulk"})
+
+	n, err := bulkEnrichCall.Stream(ctx, s.client, in, fn)
+	call.End(n, err)
 
-	return records, nil
+	return err
 }
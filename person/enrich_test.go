@@ -0,0 +1,105 @@
+package person_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+// respond returns a service whose API always answers with body.
+func respond(t *testing.T, body string) *person.Service {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := nymeria.NewClient(nymeria.WithBaseURL(srv.URL))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return person.NewService(c)
+}
+
+func TestBulkEnrichCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string // the person returned for rows 1 and 2; "" for none
+	}{
+		{
+			name: "reordered",
+			body: `{"data":[{"status":200,"metadata":{"row":2},"data":{"id":"B"}},{"status":200,"metadata":{"row":1},"data":{"id":"A"}}]}`,
+			want: []string{"A", "B"},
+		},
+		{
+			name: "mismatched metadata",
+			body: `{"data":[{"status":200,"metadata":{"row":9},"data":{"id":"C"}},{"status":200,"metadata":{"row":2},"data":{"id":"B"}}]}`,
+			want: []string{"", "B"},
+		},
+		{
+			name: "no metadata echoed",
+			body: `{"data":[{"status":200,"data":{"id":"A"}},{"status":200,"data":{"id":"B"}}]}`,
+			want: []string{"A", "B"},
+		},
+	}
+
+	params := []person.BulkEnrichParams{
+		{Params: person.EnrichParams{Email: "a@nymeria.io"}, MetaData: map[string]int{"row": 1}},
+		{Params: person.EnrichParams{Email: "b@nymeria.io"}, MetaData: map[string]int{"row": 2}},
+	}
+
+	check := func(t *testing.T, r person.BulkEnrichResult, want string) {
+		got := ""
+
+		if r.Person != nil {
+			got = r.Person.ID
+		}
+
+		if got != want {
+			t.Errorf("input %d: got person %q, want %q", r.Index, got, want)
+		}
+
+		if m, ok := r.MetaData.(map[string]int); !ok || m["row"] != r.Index+1 {
+			t.Errorf("input %d: metadata replaced with %v", r.Index, r.MetaData)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people := respond(t, tt.body)
+
+			results, err := people.BulkEnrich(params...)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, r := range results {
+				check(t, r, tt.want[i])
+			}
+
+			in := make(chan person.BulkEnrichParams, len(params))
+
+			for _, p := range params {
+				in <- p
+			}
+
+			close(in)
+
+			err = people.BulkEnrichStream(context.Background(), in, func(r person.BulkEnrichResult) error {
+				check(t, r, tt.want[r.Index])
+				return nil
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
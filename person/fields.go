@@ -0,0 +1,383 @@
+package person
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Field is a kind of data a person profile may have, matching one of the
+// availability flags of PersonPreview.
+type Field uint8
+
+const (
+	FieldGender Field = iota
+	FieldAge
+	FieldBirthYear
+	FieldBirthDate
+	FieldWorkEmail
+	FieldPersonalEmails
+	FieldEmails
+	FieldMobilePhone
+	FieldPhoneNumbers
+	FieldIndustry
+	FieldLocationLastUpdated
+	FieldLocationCountry
+	FieldInferredExperience
+	FieldInferredSalary
+	FieldJobTitleRole
+	FieldJobTitleLevels
+	FieldJobStartDate
+	FieldJobCompanyURL
+	FieldJobCompanyFounded
+	FieldJobCompanySize
+	FieldJobCompanyLinkedinURL
+	FieldJobLastUpdated
+	FieldJobSummary
+	FieldSkills
+	FieldInterests
+	FieldLinkedinUsername
+	FieldLinkedinURL
+	FieldLinkedinID
+	FieldLinkedinConnections
+	FieldFacebookUsername
+	FieldFacebookURL
+	FieldFacebookID
+	FieldTwitterUsername
+	FieldTwitterURL
+	FieldGithubUsername
+	FieldGithubURL
+	FieldProfiles
+	FieldLinkedinSummary
+	FieldEducation
+	FieldExperience
+	FieldCertificates
+	FieldLanguages
+
+	numFields
+)
+
+// String returns the field's JSON name, such as "work_email".
+func (f Field) String() string {
+	if f >= numFields {
+		return fmt.Sprintf("Field(%d)", uint8(f))
+	}
+
+	return fieldNames[f]
+}
+
+// ParseField returns the field with the given JSON name.
+func ParseField(name string) (Field, error) {
+	name = strings.TrimSpace(name)
+
+	for f, n := range fieldNames {
+		if n == name {
+			return Field(f), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unknown field %q", nymeria.ErrInvalidParameters, name)
+}
+
+// FieldSet is a set of Fields. The zero value is the empty set.
+type FieldSet uint64
+
+// AllFields holds every Field.
+const AllFields = FieldSet(1)<<numFields - 1
+
+// Fields returns the set holding fs.
+func Fields(fs ...Field) FieldSet {
+	var s FieldSet
+
+	for _, f := range fs {
+		if f < numFields {
+			s |= 1 << f
+		}
+	}
+
+	return s
+}
+
+// ParseFieldSet parses a comma separated list of JSON field names, the form
+// returned by FieldSet.String.
+func ParseFieldSet(names string) (FieldSet, error) {
+	var s FieldSet
+
+	if len(strings.TrimSpace(names)) == 0 {
+		return s, nil
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		f, err := ParseField(name)
+
+		if err != nil {
+			return 0, err
+		}
+
+		s |= 1 << f
+	}
+
+	return s, nil
+}
+
+// Has reports whether s holds every one of fs.
+func (s FieldSet) Has(fs ...Field) bool {
+	want := Fields(fs...)
+	return s&want == want
+}
+
+// Missing returns the fields of required that s does not hold.
+func (s FieldSet) Missing(required FieldSet) FieldSet {
+	return required &^ s
+}
+
+// Intersect returns the fields held by both s and o.
+func (s FieldSet) Intersect(o FieldSet) FieldSet {
+	return s & o
+}
+
+// Union returns the fields held by either s or o.
+func (s FieldSet) Union(o FieldSet) FieldSet {
+	return s | o
+}
+
+// Len returns the number of fields in s.
+func (s FieldSet) Len() int {
+	return bits.OnesCount64(uint64(s & AllFields))
+}
+
+// Fields returns the fields in s in declaration order.
+func (s FieldSet) Fields() []Field {
+	var fs []Field
+
+	for f := Field(0); f < numFields; f++ {
+		if s&(1<<f) != 0 {
+			fs = append(fs, f)
+		}
+	}
+
+	return fs
+}
+
+// String returns the JSON names of the fields in s, separated by commas.
+func (s FieldSet) String() string {
+	names := []string{}
+
+	for _, f := range s.Fields() {
+		names = append(names, f.String())
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (s FieldSet) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *FieldSet) UnmarshalText(text []byte) error {
+	parsed, err := ParseFieldSet(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+	return nil
+}
+
+// Fields returns the set of fields the preview reports data for.
+func (p PersonPreview) Fields() FieldSet {
+	var s FieldSet
+
+	for f, has := range previewFlags {
+		if has(&p) {
+			s |= 1 << f
+		}
+	}
+
+	return s
+}
+
+// Fields returns the set of fields the person has data for.
+func (p Person) Fields() FieldSet {
+	var s FieldSet
+
+	for f, has := range personFlags {
+		if has(&p) {
+			s |= 1 << f
+		}
+	}
+
+	return s
+}
+
+// Coverage counts how often each field is available across many profiles,
+// for example to report the contact coverage of a list.
+type Coverage struct {
+	Total  int
+	Counts [numFields]int
+}
+
+// Add counts the fields of one profile.
+func (c *Coverage) Add(s FieldSet) {
+	c.Total++
+
+	for _, f := range s.Fields() {
+		c.Counts[f]++
+	}
+}
+
+// Count returns the number of profiles that had f.
+func (c *Coverage) Count(f Field) int {
+	if f >= numFields {
+		return 0
+	}
+
+	return c.Counts[f]
+}
+
+// Ratio returns the share of profiles, between 0 and 1, that had f.
+func (c *Coverage) Ratio(f Field) float64 {
+	if c.Total == 0 {
+		return 0
+	}
+
+	return float64(c.Count(f)) / float64(c.Total)
+}
+
+var fieldNames = [numFields]string{
+	FieldGender:                "gender",
+	FieldAge:                   "age",
+	FieldBirthYear:             "birth_year",
+	FieldBirthDate:             "birth_date",
+	FieldWorkEmail:             "work_email",
+	FieldPersonalEmails:        "personal_emails",
+	FieldEmails:                "emails",
+	FieldMobilePhone:           "mobile_phone",
+	FieldPhoneNumbers:          "phone_numbers",
+	FieldIndustry:              "industry",
+	FieldLocationLastUpdated:   "location_last_updated",
+	FieldLocationCountry:       "location_country",
+	FieldInferredExperience:    "inferred_years_of_experience",
+	FieldInferredSalary:        "inferred_salary",
+	FieldJobTitleRole:          "job_title_role",
+	FieldJobTitleLevels:        "job_title_levels",
+	FieldJobStartDate:          "job_start_date",
+	FieldJobCompanyURL:         "job_company_website",
+	FieldJobCompanyFounded:     "job_company_founded",
+	FieldJobCompanySize:        "job_company_size",
+	FieldJobCompanyLinkedinURL: "job_company_linkedin_url",
+	FieldJobLastUpdated:        "job_last_updated",
+	FieldJobSummary:            "job_summary",
+	FieldSkills:                "skills",
+	FieldInterests:             "interests",
+	FieldLinkedinUsername:      "linkedin_username",
+	FieldLinkedinURL:           "linkedin_url",
+	FieldLinkedinID:            "linkedin_id",
+	FieldLinkedinConnections:   "linkedin_connections",
+	FieldFacebookUsername:      "facebook_username",
+	FieldFacebookURL:           "facebook_url",
+	FieldFacebookID:            "facebook_id",
+	FieldTwitterUsername:       "twitter_username",
+	FieldTwitterURL:            "twitter_url",
+	FieldGithubUsername:        "github_username",
+	FieldGithubURL:             "github_url",
+	FieldProfiles:              "profiles",
+	FieldLinkedinSummary:       "linkedin_summary",
+	FieldEducation:             "education",
+	FieldExperience:            "experience",
+	FieldCertificates:          "certificates",
+	FieldLanguages:             "languages",
+}
+
+var previewFlags = [numFields]func(*PersonPreview) bool{
+	FieldGender:                func(p *PersonPreview) bool { return p.Gender },
+	FieldAge:                   func(p *PersonPreview) bool { return p.Age },
+	FieldBirthYear:             func(p *PersonPreview) bool { return p.BirthYear },
+	FieldBirthDate:             func(p *PersonPreview) bool { return p.BirthDate },
+	FieldWorkEmail:             func(p *PersonPreview) bool { return p.WorkEmail },
+	FieldPersonalEmails:        func(p *PersonPreview) bool { return p.PersonalEmails },
+	FieldEmails:                func(p *PersonPreview) bool { return p.Emails },
+	FieldMobilePhone:           func(p *PersonPreview) bool { return p.MobilePhone },
+	FieldPhoneNumbers:          func(p *PersonPreview) bool { return p.PhoneNumbers },
+	FieldIndustry:              func(p *PersonPreview) bool { return p.Industry },
+	FieldLocationLastUpdated:   func(p *PersonPreview) bool { return p.LocationLastUpdated },
+	FieldLocationCountry:       func(p *PersonPreview) bool { return p.LocationCountry },
+	FieldInferredExperience:    func(p *PersonPreview) bool { return p.InferredExperience },
+	FieldInferredSalary:        func(p *PersonPreview) bool { return p.InferredSalary },
+	FieldJobTitleRole:          func(p *PersonPreview) bool { return p.JobTitleRole },
+	FieldJobTitleLevels:        func(p *PersonPreview) bool { return p.JobTitleLevels },
+	FieldJobStartDate:          func(p *PersonPreview) bool { return p.JobStartDate },
+	FieldJobCompanyURL:         func(p *PersonPreview) bool { return p.JobCompanyURL },
+	FieldJobCompanyFounded:     func(p *PersonPreview) bool { return p.JobCompanyFounded },
+	FieldJobCompanySize:        func(p *PersonPreview) bool { return p.JobCompanySize },
+	FieldJobCompanyLinkedinURL: func(p *PersonPreview) bool { return p.JobCompanyLinkedinURL },
+	FieldJobLastUpdated:        func(p *PersonPreview) bool { return p.JobLastUpdated },
+	FieldJobSummary:            func(p *PersonPreview) bool { return p.JobSummary },
+	FieldSkills:                func(p *PersonPreview) bool { return p.Skills },
+	FieldInterests:             func(p *PersonPreview) bool { return p.Interests },
+	FieldLinkedinUsername:      func(p *PersonPreview) bool { return p.LinkedinUsername },
+	FieldLinkedinURL:           func(p *PersonPreview) bool { return p.LinkedinURL },
+	FieldLinkedinID:            func(p *PersonPreview) bool { return p.LinkedinID },
+	FieldLinkedinConnections:   func(p *PersonPreview) bool { return p.LinkedinConnections },
+	FieldFacebookUsername:      func(p *PersonPreview) bool { return p.FacebookUsername },
+	FieldFacebookURL:           func(p *PersonPreview) bool { return p.FacebookURL },
+	FieldFacebookID:            func(p *PersonPreview) bool { return p.FacebookID },
+	FieldTwitterUsername:       func(p *PersonPreview) bool { return p.TwitterUsername },
+	FieldTwitterURL:            func(p *PersonPreview) bool { return p.TwitterURL },
+	FieldGithubUsername:        func(p *PersonPreview) bool { return p.GithubUsername },
+	FieldGithubURL:             func(p *PersonPreview) bool { return p.GithubURL },
+	FieldProfiles:              func(p *PersonPreview) bool { return p.Profiles },
+	FieldLinkedinSummary:       func(p *PersonPreview) bool { return p.LinkedinSummary },
+	FieldEducation:             func(p *PersonPreview) bool { return p.Education },
+	FieldExperience:            func(p *PersonPreview) bool { return p.Experience },
+	FieldCertificates:          func(p *PersonPreview) bool { return p.Certificates },
+	FieldLanguages:             func(p *PersonPreview) bool { return p.Languages },
+}
+
+var personFlags = [numFields]func(*Person) bool{
+	FieldGender:                func(p *Person) bool { return p.Gender != nil },
+	FieldAge:                   func(p *Person) bool { return p.Age != nil },
+	FieldBirthYear:             func(p *Person) bool { return p.BirthYear != nil },
+	FieldBirthDate:             func(p *Person) bool { return p.BirthDate != nil },
+	FieldWorkEmail:             func(p *Person) bool { return p.WorkEmail != nil },
+	FieldPersonalEmails:        func(p *Person) bool { return len(p.PersonalEmails) > 0 },
+	FieldEmails:                func(p *Person) bool { return len(p.Emails) > 0 },
+	FieldMobilePhone:           func(p *Person) bool { return p.MobilePhone != nil },
+	FieldPhoneNumbers:          func(p *Person) bool { return len(p.PhoneNumbers) > 0 },
+	FieldIndustry:              func(p *Person) bool { return p.Industry != nil },
+	FieldLocationLastUpdated:   func(p *Person) bool { return p.LocationLastUpdated != nil },
+	FieldLocationCountry:       func(p *Person) bool { return p.LocationCountry != nil },
+	FieldInferredExperience:    func(p *Person) bool { return p.InferredExperience != nil },
+	FieldInferredSalary:        func(p *Person) bool { return p.InferredSalary != nil },
+	FieldJobTitleRole:          func(p *Person) bool { return p.JobTitleRole != nil },
+	FieldJobTitleLevels:        func(p *Person) bool { return len(p.JobTitleLevels) > 0 },
+	FieldJobStartDate:          func(p *Person) bool { return p.JobStartDate != nil },
+	FieldJobCompanyURL:         func(p *Person) bool { return p.JobCompanyURL != nil },
+	FieldJobCompanyFounded:     func(p *Person) bool { return p.JobCompanyFounded != nil },
+	FieldJobCompanySize:        func(p *Person) bool { return p.JobCompanySize != nil },
+	FieldJobCompanyLinkedinURL: func(p *Person) bool { return p.JobCompanyLinkedinURL != nil },
+	FieldJobLastUpdated:        func(p *Person) bool { return p.JobLastUpdated != nil },
+	FieldJobSummary:            func(p *Person) bool { return p.JobSummary != nil },
+	FieldSkills:                func(p *Person) bool { return len(p.Skills) > 0 },
+	FieldInterests:             func(p *Person) bool { return len(p.Interests) > 0 },
+	FieldLinkedinUsername:      func(p *Person) bool { return p.LinkedinUsername != nil },
+	FieldLinkedinURL:           func(p *Person) bool { return p.LinkedinURL != nil },
+	FieldLinkedinID:            func(p *Person) bool { return p.LinkedinID != nil },
+	FieldLinkedinConnections:   func(p *Person) bool { return p.LinkedinConnections != nil },
+	FieldFacebookUsername:      func(p *Person) bool { return p.FacebookUsername != nil },
+	FieldFacebookURL:           func(p *Person) bool { return p.FacebookURL != nil },
+	FieldFacebookID:            func(p *Person) bool { return p.FacebookID != nil },
+	FieldTwitterUsername:       func(p *Person) bool { return p.TwitterUsername != nil },
+	FieldTwitterURL:            func(p *Person) bool { return p.TwitterURL != nil },
+	FieldGithubUsername:        func(p *Person) bool { return p.GithubUsername != nil },
+	FieldGithubURL:             func(p *Person) bool { return p.GithubURL != nil },
+	FieldProfiles:              func(p *Person) bool { return len(p.Profiles) > 0 },
+	FieldLinkedinSummary:       func(p *Person) bool { return p.LinkedinSummary != nil },
+	FieldEducation:             func(p *Person) bool { return len(p.Education) > 0 },
+	FieldExperience:            func(p *Person) bool { return len(p.Experience) > 0 },
+	FieldCertificates:          func(p *Person) bool { return len(p.Certificates) > 0 },
+	FieldLanguages:             func(p *Person) bool { return len(p.Languages) > 0 },
+}
@@ -0,0 +1,108 @@
+package person_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestFieldSetRoundTrip(t *testing.T) {
+	sets := []person.FieldSet{
+		0,
+		person.Fields(person.FieldWorkEmail),
+		person.Fields(person.FieldGender, person.FieldMobilePhone, person.FieldLanguages),
+		person.AllFields,
+	}
+
+	for _, s := range sets {
+		parsed, err := person.ParseFieldSet(s.String())
+
+		if err != nil || parsed != s {
+			t.Errorf("ParseFieldSet(%q) = %v, %v; want %v", s.String(), parsed, err, s)
+		}
+
+		bs, err := json.Marshal(map[string]person.FieldSet{"fields": s})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded map[string]person.FieldSet
+
+		if err := json.Unmarshal(bs, &decoded); err != nil || decoded["fields"] != s {
+			t.Errorf("%s: decoded %v, %v; want %v", bs, decoded["fields"], err, s)
+		}
+	}
+
+	if got := person.AllFields.Len(); got != len(person.AllFields.Fields()) {
+		t.Errorf("AllFields.Len() = %d, but it lists %d fields", got, len(person.AllFields.Fields()))
+	}
+
+	if got := person.Fields(person.FieldWorkEmail, person.FieldGender).String(); got != "gender,work_email" {
+		t.Errorf("got %q, want the fields in declaration order", got)
+	}
+
+	for _, names := range []string{"work_email,", "work_email, nope", "WORK_EMAIL"} {
+		if _, err := person.ParseFieldSet(names); !errors.Is(err, nymeria.ErrInvalidParameters) {
+			t.Errorf("ParseFieldSet(%q) = %v, want ErrInvalidParameters", names, err)
+		}
+	}
+}
+
+func TestFieldSetOperations(t *testing.T) {
+	have := person.Fields(person.FieldWorkEmail, person.FieldSkills, person.FieldGender)
+	required := person.Fields(person.FieldWorkEmail, person.FieldMobilePhone, person.FieldSkills)
+
+	if got, want := have.Missing(required), person.Fields(person.FieldMobilePhone); got != want {
+		t.Errorf("Missing: got %v, want %v", got, want)
+	}
+
+	if got := required.Missing(required); got != 0 {
+		t.Errorf("Missing of itself: got %v, want none", got)
+	}
+
+	if got, want := have.Intersect(required), person.Fields(person.FieldWorkEmail, person.FieldSkills); got != want {
+		t.Errorf("Intersect: got %v, want %v", got, want)
+	}
+
+	if got := have.Union(required); got.Len() != 4 || !got.Has(person.FieldGender, person.FieldMobilePhone) {
+		t.Errorf("Union: got %v", got)
+	}
+
+	if have.Has(person.FieldMobilePhone) || !have.Has() {
+		t.Errorf("Has: got wrong answers for %v", have)
+	}
+}
+
+func TestPreviewFields(t *testing.T) {
+	work, phone := "dev@nymeria.io", "+15555550100"
+	p := person.Person{ID: "dev", WorkEmail: &work, MobilePhone: &phone, Skills: []string{"go"}}
+
+	want := person.Fields(person.FieldWorkEmail, person.FieldMobilePhone, person.FieldSkills)
+
+	if got := p.Fields(); got != want {
+		t.Errorf("Person.Fields: got %v, want %v", got, want)
+	}
+
+	if got := nymeriatest.Preview(p).Fields(); got != want {
+		t.Errorf("PersonPreview.Fields: got %v, want %v", got, want)
+	}
+
+	var c person.Coverage
+
+	c.Add(want)
+	c.Add(person.Fields(person.FieldWorkEmail))
+
+	if c.Count(person.FieldWorkEmail) != 2 || c.Ratio(person.FieldMobilePhone) != 0.5 || c.Ratio(person.FieldGender) != 0 {
+		t.Errorf("got coverage %+v", c.Counts)
+	}
+
+	if !reflect.DeepEqual(want.Fields(), []person.Field{person.FieldWorkEmail, person.FieldMobilePhone, person.FieldSkills}) {
+		t.Errorf("got fields %v", want.Fields())
+	}
+}
@@ -0,0 +1,135 @@
+package person
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Condition is a test a preview must pass before PreviewThenEnrich spends
+// credits on the enrichment. Name identifies the condition when it fails.
+type Condition struct {
+	Name string
+	Test func(PersonPreview) bool
+}
+
+// HasField returns a condition that passes when the preview reports data for
+// the field with the given JSON name, such as "work_email" or "mobile_phone".
+func HasField(name string) Condition {
+	f, err := ParseField(name)
+
+	if err != nil {
+		return Condition{Name: name}
+	}
+
+	return fieldCondition(f)
+}
+
+// HasFields returns a condition per field of required, each passing when the
+// preview reports data for that field.
+func HasFields(required FieldSet) []Condition {
+	var conditions []Condition
+
+	for _, f := range required.Fields() {
+		conditions = append(conditions, fieldCondition(f))
+	}
+
+	return conditions
+}
+
+func fieldCondition(f Field) Condition {
+	return Condition{Name: f.String(), Test: func(p PersonPreview) bool {
+		return previewFlags[f](&p)
+	}}
+}
+
+// Predicate returns a condition named name that passes when fn returns true.
+func Predicate(name string, fn func(PersonPreview) bool) Condition {
+	return Condition{Name: name, Test: fn}
+}
+
+// ConditionError is returned by PreviewThenEnrich when the preview fails one
+// or more conditions. No enrichment was performed.
+type ConditionError struct {
+	Failed  []string       // the names of the failed conditions, in order
+	Preview *PersonPreview // the preview the conditions were tested against
+}
+
+func (e *ConditionError) Error() string {
+	return fmt.Sprintf("error: preview failed %s; enrichment skipped", strings.Join(e.Failed, ", "))
+}
+
+func PreviewThenEnrich(params EnrichParams, conditions ...Condition) (*Person, error) {
+	return std().PreviewThenEnrich(params, conditions...)
+}
+
+func PreviewThenEnrichContext(ctx context.Context, params EnrichParams, conditions ...Condition) (*Person, error) {
+	return std().PreviewThenEnrichContext(ctx, params, conditions...)
+}
+
+func (s *Service) PreviewThenEnrich(params EnrichParams, conditions ...Condition) (*Person, error) {
+	return s.PreviewThenEnrichContext(context.Background(), params, conditions...)
+}
+
+// PreviewThenEnrichContext previews the person identified by params and
+// enriches them only if the preview passes every condition. Otherwise a
+// *ConditionError naming the failed conditions is returned and no enrichment
+// credits are spent.
+func (s *Service) PreviewThenEnrichContext(ctx context.Context, params EnrichParams, conditions ...Condition) (*Person, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.PreviewThenEnrich", Endpoint: "/person/enrich"})
+
+	record, err := s.previewThenEnrich(ctx, params, conditions)
+
+	if err != nil {
+		call.End(0, err)
+		return nil, err
+	}
+
+	call.End(1, nil)
+
+	return record, nil
+}
+
+func (s *Service) previewThenEnrich(ctx context.Context, params EnrichParams, conditions []Condition) (*Person, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, c := range conditions {
+		if c.Test == nil {
+			return nil, fmt.Errorf("%w: unknown condition %q", nymeria.ErrInvalidParameters, c.Name)
+		}
+	}
+
+	preview, err := s.PreviewContext(ctx, PreviewParams(params))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := check(preview, conditions); err != nil {
+		return nil, err
+	}
+
+	return s.EnrichContext(ctx, params)
+}
+
+// check tests preview against conditions, returning a *ConditionError listing
+// every condition that failed.
+func check(preview *PersonPreview, conditions []Condition) error {
+	var failed []string
+
+	for _, c := range conditions {
+		if !c.Test(*preview) {
+			failed = append(failed, c.Name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &ConditionError{Failed: failed, Preview: preview}
+}
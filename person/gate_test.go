@@ -0,0 +1,70 @@
+package person_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestPreviewThenEnrich(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	work, phone := "dev@nymeria.io", "+15555550100"
+	srv.AddPerson(person.Person{ID: "dev", WorkEmail: &work, MobilePhone: &phone})
+
+	people := person.NewService(srv.Client())
+	params := person.EnrichParams{Email: work}
+
+	p, err := people.PreviewThenEnrich(params, person.HasFields(person.Fields(person.FieldWorkEmail, person.FieldMobilePhone))...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.ID != "dev" {
+		t.Errorf("got person %q, want dev", p.ID)
+	}
+
+	if n := len(srv.RequestsTo("/person/enrich")); n != 1 {
+		t.Errorf("got %d enrich requests after passing, want 1", n)
+	}
+
+	srv.Reset()
+
+	_, err = people.PreviewThenEnrich(params,
+		person.HasField("work_email"),
+		person.HasField("personal_emails"),
+		person.Predicate("named", func(p person.PersonPreview) bool { return len(p.FullName) > 0 }),
+	)
+
+	var condErr *person.ConditionError
+
+	if !errors.As(err, &condErr) {
+		t.Fatalf("got %v, want a *ConditionError", err)
+	}
+
+	if want := []string{"personal_emails", "named"}; !reflect.DeepEqual(condErr.Failed, want) {
+		t.Errorf("got failed conditions %q, want %q", condErr.Failed, want)
+	}
+
+	if n := len(srv.RequestsTo("/person/enrich/preview")); n != 1 {
+		t.Errorf("got %d preview requests, want 1", n)
+	}
+
+	if n := len(srv.RequestsTo("/person/enrich")); n != 0 {
+		t.Errorf("got %d enrich requests after a failed condition, want 0", n)
+	}
+
+	if _, err := people.PreviewThenEnrich(params, person.HasField("no_such_field")); !errors.Is(err, nymeria.ErrInvalidParameters) {
+		t.Errorf("got %v for an unknown field, want ErrInvalidParameters", err)
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("got %d requests in total, want only the first preview", n)
+	}
+}
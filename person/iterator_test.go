@@ -0,0 +1,85 @@
+package person_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestSearchAllPages(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	title := "engineer"
+
+	for i := 0; i < 250; i++ {
+		srv.AddPerson(person.Person{ID: fmt.Sprintf("p%03d", i), JobTitle: &title})
+	}
+
+	tests := []struct {
+		max    int
+		want   int
+		limits []string // the limit of each page request
+	}{
+		{max: 5, want: 5, limits: []string{"5"}},
+		{max: 150, want: 150, limits: []string{"100", "50"}},
+		{max: 0, want: 250, limits: []string{"100", "100", "100"}},
+	}
+
+	people := person.NewService(srv.Client())
+
+	for _, tt := range tests {
+		srv.Reset()
+
+		records, err := people.SearchAll(context.Background(), person.SearchParams{Title: title}, tt.max)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(records) != tt.want {
+			t.Errorf("max %d: got %d records, want %d", tt.max, len(records), tt.want)
+		}
+
+		var limits []string
+
+		for _, r := range srv.RequestsTo("/person/search") {
+			limits = append(limits, r.Query.Get("limit"))
+		}
+
+		if !reflect.DeepEqual(limits, tt.limits) {
+			t.Errorf("max %d: got page limits %v, want %v", tt.max, limits, tt.limits)
+		}
+	}
+}
+
+func TestSearchIteratorCancel(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	title := "engineer"
+
+	for i := 0; i < 20; i++ {
+		srv.AddPerson(person.Person{ID: fmt.Sprintf("p%03d", i), JobTitle: &title})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := person.NewService(srv.Client()).NewSearchIterator(ctx, person.SearchParams{Title: title, Limit: 5}, 0)
+
+	for i := 0; i < 5 && it.Next(); i++ {
+	}
+
+	cancel()
+
+	if it.Next() {
+		t.Error("iterator continued past a cancelled context")
+	}
+
+	if it.Err() != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", it.Err())
+	}
+}
@@ -0,0 +1,103 @@
+package person
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// Filter removes kinds of data from an enrichment or preview.
+type Filter string
+
+const (
+	// FilterProfessionalEmails removes professional emails so only personal
+	// emails are returned.
+	FilterProfessionalEmails Filter = "professional-emails"
+)
+
+var filters = map[Filter]bool{
+	FilterProfessionalEmails: true,
+}
+
+// Validate reports an error wrapping nymeria.ErrInvalidParameters if f is not
+// a known filter. The empty Filter is valid.
+func (f Filter) Validate() error {
+	if len(f) == 0 || filters[f] {
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown filter %q", nymeria.ErrInvalidParameters, string(f))
+}
+
+// Requirement makes an enrichment or preview return a result only if the
+// profile has the required data. Combine requirements with Require.
+type Requirement string
+
+const (
+	RequireEmail             Requirement = "email"
+	RequirePhone             Requirement = "phone"
+	RequireProfessionalEmail Requirement = "professional-email"
+	RequirePersonalEmail     Requirement = "personal-email"
+)
+
+var requirements = map[Requirement]bool{
+	RequireEmail:             true,
+	RequirePhone:             true,
+	RequireProfessionalEmail: true,
+	RequirePersonalEmail:     true,
+}
+
+// Require combines requirements into the comma separated form the API
+// expects. For example, Require(RequirePhone, RequirePersonalEmail) is
+// "phone,personal-email".
+func Require(reqs ...Requirement) Requirement {
+	parts := make([]string, 0, len(reqs))
+
+	for _, r := range reqs {
+		if len(r) > 0 {
+			parts = append(parts, string(r))
+		}
+	}
+
+	return Requirement(strings.Join(parts, ","))
+}
+
+// Split returns the individual requirements of a combined Requirement. Parts
+// are not trimmed, so Split and Validate see exactly what is sent.
+func (r Requirement) Split() []Requirement {
+	if len(r) == 0 {
+		return nil
+	}
+
+	var reqs []Requirement
+
+	for _, part := range strings.Split(string(r), ",") {
+		reqs = append(reqs, Requirement(part))
+	}
+
+	return reqs
+}
+
+// Validate reports an error wrapping nymeria.ErrInvalidParameters if any
+// requirement in r is unknown. The API expects requirements separated by
+// commas alone, so "phone, email" is rejected. The empty Requirement is
+// valid.
+func (r Requirement) Validate() error {
+	for _, req := range r.Split() {
+		if !requirements[req] {
+			return fmt.Errorf("%w: unknown requirement %q", nymeria.ErrInvalidParameters, string(req))
+		}
+	}
+
+	return nil
+}
+
+// validateOptions checks the filter and requirement of a request.
+func validateOptions(f Filter, r Requirement) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	return r.Validate()
+}
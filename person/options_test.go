@@ -0,0 +1,51 @@
+package person_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestRequirementValidate(t *testing.T) {
+	tests := []struct {
+		require person.Requirement
+		valid   bool
+	}{
+		{"", true},
+		{person.RequirePhone, true},
+		{person.Require(person.RequirePhone, person.RequirePersonalEmail), true},
+		{"phone,email", true},
+		{"phone, email", false},
+		{"phone,", false},
+		{"mobile", false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.require.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Requirement(%q).Validate() = %v, want valid %v", tt.require, err, tt.valid)
+		}
+	}
+}
+
+func TestEnrichRejectsUnknownOptions(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	people := person.NewService(srv.Client())
+
+	for _, params := range []person.EnrichParams{
+		{Email: "dev@nymeria.io", Require: "phone, email"},
+		{Email: "dev@nymeria.io", Filter: "work-emails"},
+	} {
+		if _, err := people.Enrich(params); !errors.Is(err, nymeria.ErrInvalidParameters) {
+			t.Errorf("%+v: got %v, want ErrInvalidParameters", params, err)
+		}
+	}
+
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
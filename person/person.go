@@ -103,3 +103,11 @@ type Certificate struct {
 	EndDate      *string `json:"end_date"`
 	StartDate    *string `json:"start_date"`
 }
+
+// bulkItem is one entry of a bulk enrich or retrieve response.
+type bulkItem struct {
+	Status   int         `json:"status"`
+	Message  string      `json:"message"`
+	MetaData interface{} `json:"metadata"`
+	Data     *Person     `json:"data"`
+}
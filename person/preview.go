@@ -1,14 +1,13 @@
 package person
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
 type PersonPreview struct {
@@ -68,14 +67,24 @@ type PreviewParams struct {
 	Profile string
 	Email   string
 	LID     string
-	Filter  string
-	Require string
+	Filter  Filter
+	Require Requirement
 }
 
 func (e PreviewParams) Invalid() bool {
 	return len(e.Profile) == 0 && len(e.Email) == 0 && len(e.LID) == 0
 }
 
+// Validate reports an error wrapping nymeria.ErrInvalidParameters if no
+// profile, email or LID is given, or if Filter or Require is unknown.
+func (e PreviewParams) Validate() error {
+	if e.Invalid() {
+		return nymeria.ErrInvalidParameters
+	}
+
+	return validateOptions(e.Filter, e.Require)
+}
+
 func (e PreviewParams) URL() string {
 	var query strings.Builder
 
@@ -97,12 +106,12 @@ func (e PreviewParams) URL() string {
 	}
 
 	if len(e.Filter) > 0 {
-		query.WriteString(fmt.Sprintf("%sfilter=%s", prefix, url.QueryEscape(e.Filter)))
+		query.WriteString(fmt.Sprintf("%sfilter=%s", prefix, url.QueryEscape(string(e.Filter))))
 		prefix = "&"
 	}
 
 	if len(e.Require) > 0 {
-		query.WriteString(fmt.Sprintf("%srequire=%s", prefix, url.QueryEscape(e.Require)))
+		query.WriteString(fmt.Sprintf("%srequire=%s", prefix, url.QueryEscape(string(e.Require))))
 		prefix = "&"
 	}
 
@@ -110,44 +119,40 @@ func (e PreviewParams) URL() string {
 }
 
 func Preview(params PreviewParams) (*PersonPreview, error) {
-	if params.Invalid() {
-		return nil, nymeria.ErrInvalidParameters
-	}
+	return std().Preview(params)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/person/enrich/preview?%s", params.URL()), nil)
+func PreviewContext(ctx context.Context, params PreviewParams) (*PersonPreview, error) {
+	return std().PreviewContext(ctx, params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) Preview(params PreviewParams) (*PersonPreview, error) {
+	return s.PreviewContext(context.Background(), params)
+}
+
+func (s *Service) PreviewContext(ctx context.Context, params PreviewParams) (*PersonPreview, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.Preview", Endpoint: "/person/enrich/preview"})
 
-	resp, err := nymeria.Client.Do(req)
+	record, err := s.preview(ctx, params)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
-
-		return nil, nymeria.ErrServerError
-	}
-
-	defer resp.Body.Close()
+	call.End(1, nil)
 
-	bs, err := io.ReadAll(resp.Body)
+	return record, nil
+}
 
-	if err != nil {
+func (s *Service) preview(ctx context.Context, params PreviewParams) (*PersonPreview, error) {
+	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 
-	var response struct {
-		Status int           `json:"status"`
-		Data   PersonPreview `json:"data"`
-	}
+	response, err := transport.Fetch[PersonPreview](ctx, s.client, "GET", fmt.Sprintf("/person/enrich/preview?%s", params.URL()), nil)
 
-	if err := json.Unmarshal(bs, &response); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
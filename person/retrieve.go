@@ -1,13 +1,12 @@
 package person
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
 type BulkRetrieveParams struct {
@@ -15,114 +14,158 @@ type BulkRetrieveParams struct {
 	MetaData map[string]interface{} `json:"metadata"`
 }
 
+// BulkRetrieveResult is the outcome of one BulkRetrieveParams. Results are
+// returned in input order.
+type BulkRetrieveResult struct {
+	Index    int                    // position of the input in the request
+	ID       string                 // the requested ID
+	MetaData map[string]interface{} // the input's metadata, echoed back by the API
+	Status   int                    // the item's status, e.g. 200 or 404
+	Err      error                  // nil on success; an *nymeria.ItemError otherwise
+	Person   *Person                // set on success
+}
+
+// OK reports whether the person was retrieved.
+func (r BulkRetrieveResult) OK() bool {
+	return r.Err == nil && r.Person != nil
+}
+
 func Retrieve(id string) (*Person, error) {
-	if len(id) == 0 {
-		return nil, nymeria.ErrInvalidParameters
-	}
+	return std().Retrieve(id)
+}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/person/retrieve/%s", id), nil)
+func RetrieveContext(ctx context.Context, id string) (*Person, error) {
+	return std().RetrieveContext(ctx, id)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (s *Service) Retrieve(id string) (*Person, error) {
+	return s.RetrieveContext(context.Background(), id)
+}
+
+func (s *Service) RetrieveContext(ctx context.Context, id string) (*Person, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.Retrieve", Endpoint: "/person/retrieve"})
 
-	resp, err := nymeria.Client.Do(req)
+	record, err := s.retrieve(ctx, id)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	call.End(1, nil)
 
-		return nil, nymeria.ErrServerError
-	}
+	return record, nil
+}
 
-	defer resp.Body.Close()
+func (s *Service) retrieve(ctx context.Context, id string) (*Person, error) {
+	if len(id) == 0 {
+		return nil, nymeria.ErrInvalidParameters
+	}
 
-	bs, err := io.ReadAll(resp.Body)
+	response, err := transport.Fetch[Person](ctx, s.client, "GET", fmt.Sprintf("/person/retrieve/%s", url.PathEscape(id)), nil)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var response struct {
-		Status int    `json:"status"`
-		Data   Person `json:"data"`
-	}
+	return &response.Data, nil
+}
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
-	}
+func BulkRetrieve(params ...BulkRetrieveParams) ([]BulkRetrieveResult, error) {
+	return std().BulkRetrieve(params...)
+}
 
-	return &response.Data, nil
+func BulkRetrieveContext(ctx context.Context, params ...BulkRetrieveParams) ([]BulkRetrieveResult, error) {
+	return std().BulkRetrieveContext(ctx, params...)
 }
 
-func BulkRetrieve(params ...BulkRetrieveParams) ([]Person, error) {
-	if len(params) == 0 {
-		return nil, nymeria.ErrInvalidParameters
-	}
+func (s *Service) BulkRetrieve(params ...BulkRetrieveParams) ([]BulkRetrieveResult, error) {
+	return s.BulkRetrieveContext(context.Background(), params...)
+}
 
-	bs, err := json.Marshal(map[string]interface{}{
-		"requests": params,
-	})
+func (s *Service) BulkRetrieveContext(ctx context.Context, params ...BulkRetrieveParams) ([]BulkRetrieveResult, error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.BulkRetrieve", Endpoint: "/person/retrieve/bulk", BulkSize: len(params)})
 
-	if err != nil {
-		return nil, err
-	}
+	results, err := s.bulkRetrieve(ctx, params...)
+	call.End(transport.Succeeded(results), err)
 
-	req, err := nymeria.Request("POST", "/person/retrieve/bulk", bytes.NewBuffer(bs))
+	return results, err
+}
 
-	if err != nil {
-		return nil, err
+func (s *Service) bulkRetrieve(ctx context.Context, params ...BulkRetrieveParams) ([]BulkRetrieveResult, error) {
+	if len(params) == 0 {
+		return nil, nymeria.ErrInvalidParameters
 	}
 
-	req.Header.Add("Content-Type", "application/json")
+	return bulkRetrieveCall.Send(ctx, s.client, params)
+}
 
-	resp, err := nymeria.Client.Do(req)
+// bulkRetrieveCall describes the bulk retrieve endpoint.
+var bulkRetrieveCall = transport.Bulk[BulkRetrieveParams, bulkItem, BulkRetrieveResult]{
+	Endpoint: "/person/retrieve/bulk",
+	Body: func(offset int, batch []BulkRetrieveParams) (interface{}, error) {
+		return map[string]interface{}{"requests": batch}, nil
+	},
+	Correlate: correlate,
+	Result:    retrieveResult,
+	Fail: func(index int, p BulkRetrieveParams, err error) BulkRetrieveResult {
+		return BulkRetrieveResult{Index: index, ID: p.ID, MetaData: p.MetaData, Err: err}
+	},
+}
 
-	if err != nil {
-		return nil, err
+// correlate matches bulk retrieve items to params by the returned person's
+// ID. Items without a person, such as a 404, fall back to their position.
+func correlate(params []BulkRetrieveParams) *transport.Correlator[bulkItem] {
+	ids := make([]string, len(params))
+
+	for i, p := range params {
+		ids[i] = p.ID
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
+	return transport.NewCorrelator(ids, func(v bulkItem) string {
+		if v.Data == nil {
+			return ""
 		}
 
-		return nil, nymeria.ErrServerError
-	}
-
-	defer resp.Body.Close()
+		return v.Data.ID
+	})
+}
 
-	bs, err = io.ReadAll(resp.Body)
+// retrieveResult builds the result of input p at index from its response
+// item, which is nil when the API returned none.
+func retrieveResult(index int, p BulkRetrieveParams, v *bulkItem) BulkRetrieveResult {
+	result := BulkRetrieveResult{Index: index, ID: p.ID, MetaData: p.MetaData}
 
-	if err != nil {
-		return nil, err
+	if v == nil {
+		result.Err = nymeria.CheckItem(index, 0, "")
+		return result
 	}
 
-	var response struct {
-		Status int `json:"status"`
-		Data   []struct {
-			Status   int                    `json:"status"`
-			MetaData map[string]interface{} `json:"metadata"`
-			Data     Person                 `json:"data"`
-		} `json:"data"`
+	result.Status = v.Status
+	result.Err = nymeria.CheckItem(index, v.Status, v.Message)
+
+	if m, ok := v.MetaData.(map[string]interface{}); ok {
+		result.MetaData = m
 	}
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
+	if result.Err == nil {
+		result.Person = v.Data
 	}
 
-	var records []Person
+	return result
+}
 
-	for _, v := range response.Data {
-		if v.Status == 200 {
-			records = append(records, v.Data)
-		}
-	}
+func BulkRetrieveStream(ctx context.Context, in <-chan BulkRetrieveParams, fn func(BulkRetrieveResult) error) error {
+	return std().BulkRetrieveStream(ctx, in, fn)
+}
+
+// BulkRetrieveStream is the streaming counterpart of BulkRetrieve; see
+// BulkEnrichStream.
+func (s *Service) BulkRetrieveStream(ctx context.Context, in <-chan BulkRetrieveParams, fn func(BulkRetrieveResult) error) error {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.BulkRetrieveStream", Endpoint: "/person/retrieve/bulk"})
+
+	n, err := bulkRetrieveCall.Stream(ctx, s.client, in, fn)
+	call.End(n, err)
 
-	return records, nil
+	return err
 }
@@ -0,0 +1,88 @@
+package person_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestBulkRetrieveCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string // the person returned for inputs A and B; "" for none
+	}{
+		{
+			name: "in order",
+			body: `{"data":[{"status":200,"data":{"id":"A"}},{"status":200,"data":{"id":"B"}}]}`,
+			want: []string{"A", "B"},
+		},
+		{
+			name: "reordered",
+			body: `{"data":[{"status":200,"data":{"id":"B"}},{"status":200,"data":{"id":"A"}}]}`,
+			want: []string{"A", "B"},
+		},
+		{
+			name: "miss in front of a claimed input",
+			body: `{"data":[{"status":200,"data":{"id":"B"}},{"status":404}]}`,
+			want: []string{"", "B"},
+		},
+		{
+			name: "unknown person",
+			body: `{"data":[{"status":200,"data":{"id":"C"}},{"status":200,"data":{"id":"B"}}]}`,
+			want: []string{"", "B"},
+		},
+	}
+
+	params := []person.BulkRetrieveParams{{ID: "A"}, {ID: "B"}}
+
+	check := func(t *testing.T, r person.BulkRetrieveResult, want string) {
+		got := ""
+
+		if r.Person != nil {
+			got = r.Person.ID
+		}
+
+		if got != want {
+			t.Errorf("input %s: got person %q, want %q", r.ID, got, want)
+		}
+
+		if r.OK() != (len(want) > 0) {
+			t.Errorf("input %s: got OK %v, err %v", r.ID, r.OK(), r.Err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people := respond(t, tt.body)
+
+			results, err := people.BulkRetrieve(params...)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, r := range results {
+				check(t, r, tt.want[i])
+			}
+
+			in := make(chan person.BulkRetrieveParams, len(params))
+
+			for _, p := range params {
+				in <- p
+			}
+
+			close(in)
+
+			err = people.BulkRetrieveStream(context.Background(), in, func(r person.BulkRetrieveResult) error {
+				check(t, r, tt.want[r.Index])
+				return nil
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
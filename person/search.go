@@ -1,40 +1,87 @@
 package person
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/internal/transport"
 )
 
+// SearchResult is one page of search results.
+type SearchResult struct {
+	Records  []Person
+	Total    int         // matching records across all pages
+	Offset   int         // position of the first record in Records
+	Limit    int         // page size used for the request
+	MetaData interface{} // metadata sent by the API as decoded JSON, if any
+}
+
+// HasMore reports whether records remain after this page.
+func (r SearchResult) HasMore() bool {
+	return r.Offset+len(r.Records) < r.Total
+}
+
+// MaxSearchLimit is the largest page of results the API returns.
+const MaxSearchLimit = 100
+
+// SearchParams filters a person search. Multi-value filters match people
+// having any of the given values.
+//
+// The API documentation does not name the query parameters of the
+// experience and contact filters, so they are assumed to be min_experience,
+// max_experience, has_email and has_phone.
 type SearchParams struct {
-	FirstName string
-	LastName  string
-	Title     string
-	Company   string
-	Country   string
-	Location  string
-	Industry  string
-	Limit     int /* how many records to retrieve starting (default: 0) */
-	Offset    int /* from which record to start */
+	FirstName     string
+	LastName      string
+	Title         string
+	Company       string
+	Country       string
+	Location      string
+	Industry      string
+	Skills        []string /* any of the person's skills */
+	Levels        []string /* job_title_levels, e.g. "senior", "director" */
+	Roles         []string /* job_title_role, e.g. "engineering", "sales" */
+	CompanySizes  []string /* job_company_size, e.g. "11-50", "51-200" */
+	MinExperience int      /* min_experience (assumed), in years */
+	MaxExperience int      /* max_experience (assumed), in years */
+	HasEmail      bool     /* has_email=true (assumed); people with an email address */
+	HasPhone      bool     /* has_phone=true (assumed); people with a phone number */
+	Limit         int      /* how many records to retrieve (default: 10, max: 100) */
+	Offset        int      /* from which record to start */
 }
 
 func (s SearchParams) Invalid() bool {
-	return len(s.FirstName) == 0 && len(s.LastName) == 0 && len(s.Title) == 0 && len(s.Company) == 0 && len(s.Country) == 0 && len(s.Industry) == 0
+	if s.MinExperience < 0 || s.MaxExperience < 0 || (s.MaxExperience > 0 && s.MinExperience > s.MaxExperience) {
+		return true
+	}
+
+	return len(s.FirstName) == 0 && len(s.LastName) == 0 && len(s.Title) == 0 && len(s.Company) == 0 &&
+		len(s.Country) == 0 && len(s.Location) == 0 && len(s.Industry) == 0 &&
+		len(s.Skills) == 0 && len(s.Levels) == 0 && len(s.Roles) == 0 && len(s.CompanySizes) == 0 &&
+		s.MinExperience == 0 && s.MaxExperience == 0 && !s.HasEmail && !s.HasPhone
 }
 
-func (s SearchParams) URL() string {
-	if s.Limit <= 0 || s.Limit > 100 {
-		s.Limit = 10
+// limit returns the page size sent to the API.
+func (s SearchParams) limit() int {
+	if s.Limit <= 0 {
+		return 10
 	}
 
+	if s.Limit > MaxSearchLimit {
+		return MaxSearchLimit
+	}
+
+	return s.Limit
+}
+
+func (s SearchParams) URL() string {
 	var query strings.Builder
 
-	query.WriteString(fmt.Sprintf("limit=%d", s.Limit))
+	query.WriteString(fmt.Sprintf("limit=%d", s.limit()))
 	query.WriteString(fmt.Sprintf("&offset=%d", s.Offset))
 
 	if len(s.FirstName) > 0 {
@@ -65,54 +112,96 @@ func (s SearchParams) URL() string {
 		query.WriteString(fmt.Sprintf("&industry=%s", url.QueryEscape(s.Industry)))
 	}
 
-	return query.String()
-}
+	for _, f := range []struct {
+		key    string
+		values []string
+	}{
+		{"skills", s.Skills},
+		{"job_title_levels", s.Levels},
+		{"job_title_role", s.Roles},
+		{"job_company_size", s.CompanySizes},
+	} {
+		for _, v := range f.values {
+			if len(v) > 0 {
+				query.WriteString(fmt.Sprintf("&%s=%s", f.key, url.QueryEscape(v)))
+			}
+		}
+	}
 
-func Search(params SearchParams) ([]Person, error) {
-	if params.Invalid() {
-		return nil, nymeria.ErrInvalidParameters
+	if s.MinExperience > 0 {
+		query.WriteString(fmt.Sprintf("&min_experience=%d", s.MinExperience))
 	}
 
-	req, err := nymeria.Request("GET", fmt.Sprintf("/person/search?%s", params.URL()), nil)
+	if s.MaxExperience > 0 {
+		query.WriteString(fmt.Sprintf("&max_experience=%d", s.MaxExperience))
+	}
 
-	if err != nil {
-		return nil, err
+	if s.HasEmail {
+		query.WriteString("&has_email=true")
 	}
 
-	resp, err := nymeria.Client.Do(req)
+	if s.HasPhone {
+		query.WriteString("&has_phone=true")
+	}
+
+	return query.String()
+}
+
+func Search(params SearchParams) (*SearchResult, error) {
+	return std().Search(params)
+}
+
+func SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	return std().SearchContext(ctx, params)
+}
+
+func (s *Service) Search(params SearchParams) (*SearchResult, error) {
+	return s.SearchContext(context.Background(), params)
+}
+
+func (s *Service) SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	response, err := s.page(ctx, params)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if e, ok := nymeria.ErrMap[resp.StatusCode]; ok {
-			return nil, e
-		}
+	result := &SearchResult{
+		Records: response.Data,
+		Total:   response.Total,
+		Offset:  params.Offset,
+		Limit:   params.limit(),
+	}
 
-		return nil, nymeria.ErrServerError
+	if len(response.MetaData) > 0 {
+		if err := json.Unmarshal(response.MetaData, &result.MetaData); err != nil {
+			return nil, err
+		}
 	}
 
-	defer resp.Body.Close()
+	return result, nil
+}
+
+// page fetches one page of results, reported as a person.Search operation.
+func (s *Service) page(ctx context.Context, params SearchParams) (*transport.Envelope[[]Person], error) {
+	ctx, call := s.client.Start(ctx, nymeria.Operation{Name: "person.Search", Endpoint: "/person/search"})
 
-	bs, err := io.ReadAll(resp.Body)
+	response, err := s.search(ctx, params)
 
 	if err != nil {
+		call.End(0, err)
 		return nil, err
 	}
 
-	var response struct {
-		Data     []Person    `json:"data"`
-		Status   int         `json:"status"`
-		MetaData interface{} `json:"metadata"`
-		Total    int         `json:"total"`
-	}
+	call.End(len(response.Data), nil)
 
-	fmt.Println(string(bs))
+	return response, nil
+}
 
-	if err := json.Unmarshal(bs, &response); err != nil {
-		return nil, err
+func (s *Service) search(ctx context.Context, params SearchParams) (*transport.Envelope[[]Person], error) {
+	if params.Invalid() {
+		return nil, nymeria.ErrInvalidParameters
 	}
 
-	return response.Data, nil
+	return transport.Fetch[[]Person](ctx, s.client, "GET", fmt.Sprintf("/person/search?%s", params.URL()), nil)
 }
@@ -0,0 +1,78 @@
+package person_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go/person"
+)
+
+func TestSearchResult(t *testing.T) {
+	tests := []struct {
+		body string
+		want interface{}
+	}{
+		{`{"status":200,"data":[{"id":"A"}],"total":3}`, nil},
+		{`{"status":200,"data":[{"id":"A"}],"total":3,"metadata":{"took":12}}`, map[string]interface{}{"took": float64(12)}},
+		{`{"status":200,"data":[{"id":"A"}],"total":3,"metadata":["cached"]}`, []interface{}{"cached"}},
+	}
+
+	for _, tt := range tests {
+		result, err := respond(t, tt.body).Search(person.SearchParams{Title: "engineer", Limit: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result.Records) != 1 || result.Total != 3 || result.Limit != 1 || !result.HasMore() {
+			t.Errorf("%s: got %+v", tt.body, result)
+		}
+
+		if !reflect.DeepEqual(result.MetaData, tt.want) {
+			t.Errorf("%s: got metadata %#v, want %#v", tt.body, result.MetaData, tt.want)
+		}
+	}
+}
+
+func TestSearchParamsURL(t *testing.T) {
+	params := person.SearchParams{
+		Skills:        []string{"go", "c++"},
+		Levels:        []string{"senior"},
+		Roles:         []string{"engineering"},
+		CompanySizes:  []string{"11-50"},
+		MinExperience: 5,
+		MaxExperience: 10,
+		HasEmail:      true,
+		HasPhone:      true,
+	}
+
+	q, err := url.ParseQuery(params.URL())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := url.Values{
+		"skills":           {"go", "c++"},
+		"job_title_levels": {"senior"},
+		"job_title_role":   {"engineering"},
+		"job_company_size": {"11-50"},
+		"min_experience":   {"5"},
+		"max_experience":   {"10"},
+		"has_email":        {"true"},
+		"has_phone":        {"true"},
+	}
+
+	for k, vs := range want {
+		if !reflect.DeepEqual(q[k], vs) {
+			t.Errorf("%s: got %q, want %q", k, q[k], vs)
+		}
+	}
+
+	for _, p := range []person.SearchParams{{MinExperience: -1}, {MinExperience: 10, MaxExperience: 5}, {}} {
+		if !p.Invalid() {
+			t.Errorf("%+v is valid", p)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package person
+
+import (
+	"context"
+
+	"github.com/nymeria-io/nymeria.go"
+)
+
+// PersonService is the set of person calls. *Service implements it; depend on
+// the interface to substitute a fake such as the ones in nymeriatest.
+type PersonService interface {
+	EnrichContext(ctx context.Context, params EnrichParams) (*Person, error)
+	BulkEnrichContext(ctx context.Context, params ...BulkEnrichParams) ([]BulkEnrichResult, error)
+	PreviewContext(ctx context.Context, params PreviewParams) (*PersonPreview, error)
+	RetrieveContext(ctx context.Context, id string) (*Person, error)
+	BulkRetrieveContext(ctx context.Context, params ...BulkRetrieveParams) ([]BulkRetrieveResult, error)
+	SearchContext(ctx context.Context, params SearchParams) (*SearchResult, error)
+}
+
+var _ PersonService = (*Service)(nil)
+
+// Service exposes the person endpoints for a single nymeria.Client.
+type Service struct {
+	client *nymeria.Client
+}
+
+// NewService returns a Service that sends its requests through c.
+func NewService(c *nymeria.Client) *Service {
+	return &Service{client: c}
+}
+
+// std returns a Service bound to nymeria.DefaultClient.
+func std() *Service {
+	return NewService(nymeria.DefaultClient)
+}
@@ -0,0 +1,183 @@
+package nymeria
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit describes a token bucket: Rate requests per second on average with
+// bursts of up to Burst requests. When Adaptive is set, the rate is halved each
+// time the server answers 429 and recovers gradually as requests succeed.
+type RateLimit struct {
+	Rate     float64
+	Burst    int
+	Adaptive bool
+}
+
+// WithRateLimit limits every request made by the client.
+func WithRateLimit(l RateLimit) Option {
+	return func(c *Client) error {
+		b, err := newBucket(l)
+
+		if err != nil {
+			return err
+		}
+
+		c.limits.global = b
+		return nil
+	}
+}
+
+// WithEndpointRateLimit limits requests to the given endpoint path, such as
+// "/person/enrich" or "/email/verify/bulk". The longest matching path wins and
+// applies in addition to any client-wide limit.
+func WithEndpointRateLimit(endpoint string, l RateLimit) Option {
+	return func(c *Client) error {
+		b, err := newBucket(l)
+
+		if err != nil {
+			return err
+		}
+
+		if c.limits.endpoints == nil {
+			c.limits.endpoints = map[string]*bucket{}
+		}
+
+		c.limits.endpoints[strings.TrimSuffix(endpoint, "/")] = b
+		return nil
+	}
+}
+
+type limiter struct {
+	global    *bucket
+	endpoints map[string]*bucket
+}
+
+// buckets returns the buckets that apply to endpoint.
+func (l *limiter) buckets(endpoint string) []*bucket {
+	var bs []*bucket
+
+	if l.global != nil {
+		bs = append(bs, l.global)
+	}
+
+	for path := endpoint; len(path) > 0; {
+		if b, ok := l.endpoints[path]; ok {
+			return append(bs, b)
+		}
+
+		i := strings.LastIndex(path, "/")
+
+		if i < 0 {
+			break
+		}
+
+		path = path[:i]
+	}
+
+	return bs
+}
+
+// wait blocks until every bucket for endpoint has a token or ctx is done.
+func (l *limiter) wait(ctx context.Context, endpoint string) error {
+	for _, b := range l.buckets(endpoint) {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// observe adapts the buckets for endpoint to the outcome of a request.
+func (l *limiter) observe(endpoint string, status int) {
+	for _, b := range l.buckets(endpoint) {
+		b.observe(status)
+	}
+}
+
+type bucket struct {
+	mu       sync.Mutex
+	limit    float64 /* configured rate */
+	rate     float64 /* current rate; below limit after 429s when adaptive */
+	burst    float64
+	tokens   float64
+	last     time.Time
+	adaptive bool
+}
+
+func newBucket(l RateLimit) (*bucket, error) {
+	if l.Rate <= 0 || l.Burst < 0 {
+		return nil, ErrInvalidParameters
+	}
+
+	if l.Burst == 0 {
+		l.Burst = 1
+	}
+
+	return &bucket{
+		limit:    l.Rate,
+		rate:     l.Rate,
+		burst:    float64(l.Burst),
+		tokens:   float64(l.Burst),
+		last:     time.Now(),
+		adaptive: l.Adaptive,
+	}, nil
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		b.last = now
+
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *bucket) observe(status int) {
+	if !b.adaptive {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if status == http.StatusTooManyRequests {
+		b.rate /= 2
+
+		if floor := b.limit / 16; b.rate < floor {
+			b.rate = floor
+		}
+
+		return
+	}
+
+	if b.rate < b.limit {
+		b.rate += b.limit / 20
+
+		if b.rate > b.limit {
+			b.rate = b.limit
+		}
+	}
+}
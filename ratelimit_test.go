@@ -0,0 +1,48 @@
+package nymeria_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+func TestRateLimitThrottles(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	emails := email.NewService(srv.Client(nymeria.WithRateLimit(nymeria.RateLimit{Rate: 50, Burst: 1})))
+	start := time.Now()
+
+	for i := 0; i < 6; i++ {
+		emails.Verify("dev@nymeria.io")
+	}
+
+	/* one token up front, then five more at 20ms each */
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("6 requests at 50/s took %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimitHonorsContext(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	emails := email.NewService(srv.Client(nymeria.WithEndpointRateLimit("/email/verify", nymeria.RateLimit{Rate: 0.1, Burst: 1})))
+	emails.Verify("dev@nymeria.io")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := emails.VerifyContext(ctx, "dev@nymeria.io"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
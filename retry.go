@@ -0,0 +1,130 @@
+package nymeria
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. The zero value
+// disables retries.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	MinBackoff  time.Duration // delay before the first retry
+	MaxBackoff  time.Duration // upper bound of any computed delay
+	Statuses    []int         // response statuses worth retrying
+	RetryBulk   bool          // also retry the bulk POST endpoints
+}
+
+// DefaultRetryPolicy retries transient failures up to three times. Enable it
+// with WithRetryPolicy(nymeria.DefaultRetryPolicy).
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+	Statuses: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetryPolicy sets the retry policy used by every call made by the client.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) error {
+		if p.MaxAttempts < 0 || p.MinBackoff < 0 || p.MaxBackoff < 0 {
+			return ErrInvalidParameters
+		}
+
+		c.retry = p
+		return nil
+	}
+}
+
+// attempts returns how many times req may be sent.
+func (p RetryPolicy) attempts(req *http.Request) int {
+	if p.MaxAttempts <= 1 {
+		return 1
+	}
+
+	if req.Method != http.MethodGet && !p.RetryBulk {
+		return 1
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// retryable reports whether err is worth another attempt.
+func (p RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) {
+		return true /* transport failure */
+	}
+
+	for _, s := range p.Statuses {
+		if s == apiErr.StatusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+// Retry-After is honored on 429 and 503 responses; otherwise the delay grows
+// exponentially with jitter.
+func (p RetryPolicy) backoff(retry int, err error) time.Duration {
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode == http.StatusServiceUnavailable {
+			return apiErr.RetryAfter
+		}
+	}
+
+	d := p.MinBackoff
+
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package nymeria_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+var fastRetries = nymeria.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+	Statuses:    []int{http.StatusServiceUnavailable},
+}
+
+func TestRetryRecovers(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	srv.AddVerification("dev@nymeria.io", email.Verification{Result: "valid"})
+	srv.Inject(nymeriatest.Fault{Path: "/email/verify", Call: 1, Status: http.StatusServiceUnavailable})
+
+	v, err := email.NewService(srv.Client(nymeria.WithRetryPolicy(fastRetries))).Verify("dev@nymeria.io")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Result != "valid" {
+		t.Errorf("got result %q, want valid", v.Result)
+	}
+
+	if n := len(srv.RequestsTo("/email/verify")); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	srv.Inject(nymeriatest.Fault{Path: "/email/verify", Status: http.StatusServiceUnavailable})
+
+	_, err := email.NewService(srv.Client(nymeria.WithRetryPolicy(fastRetries))).Verify("dev@nymeria.io")
+
+	var apiErr *nymeria.APIError
+
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want a 503 *APIError", err)
+	}
+
+	if !errors.Is(err, nymeria.ErrServerError) {
+		t.Errorf("%v does not match ErrServerError", err)
+	}
+
+	if n := len(srv.RequestsTo("/email/verify")); n != fastRetries.MaxAttempts {
+		t.Errorf("got %d requests, want %d", n, fastRetries.MaxAttempts)
+	}
+}
+
+func TestRetryBulk(t *testing.T) {
+	for _, retryBulk := range []bool{false, true} {
+		srv := nymeriatest.NewServer()
+
+		srv.AddVerification("dev@nymeria.io", email.Verification{Result: "valid"})
+		srv.Inject(nymeriatest.Fault{Path: "/email/verify/bulk", Call: 1, Status: http.StatusServiceUnavailable})
+
+		policy := fastRetries
+		policy.RetryBulk = retryBulk
+
+		_, err := email.NewService(srv.Client(nymeria.WithRetryPolicy(policy))).BulkVerify(email.BulkVerifyParams{Email: "dev@nymeria.io"})
+
+		want := 1
+
+		if retryBulk {
+			want = 2
+		}
+
+		if n := len(srv.RequestsTo("/email/verify/bulk")); n != want {
+			t.Errorf("RetryBulk %v: got %d requests, want %d", retryBulk, n, want)
+		}
+
+		if (err == nil) != retryBulk {
+			t.Errorf("RetryBulk %v: got error %v", retryBulk, err)
+		}
+
+		srv.Close()
+	}
+}
@@ -0,0 +1,114 @@
+package nymeria_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+// produce sends params on an unbuffered channel and closes done once every
+// input was sent or the producer gave up.
+func produce(params []email.BulkVerifyParams) (<-chan email.BulkVerifyParams, <-chan struct{}) {
+	in := make(chan email.BulkVerifyParams)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer close(in)
+
+		for _, p := range params {
+			in <- p
+		}
+	}()
+
+	return in, done
+}
+
+func TestBulkStream(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	params := addresses(srv, 250)
+	srv.Inject(nymeriatest.Fault{Path: "/email/verify/bulk", Call: 2, Status: http.StatusInternalServerError})
+
+	in, _ := produce(params)
+	seen := map[int]bool{}
+	failed := 0
+
+	spans := nymeria.NewMemoryInstrumentation()
+	svc := email.NewService(srv.Client(nymeria.WithBulkChunkSize(100), nymeria.WithBulkConcurrency(1), nymeria.WithInstrumentation(spans)))
+
+	err := svc.BulkVerifyStream(context.Background(), in, func(r email.BulkVerifyResult) error {
+		if seen[r.Index] {
+			t.Errorf("result %d delivered twice", r.Index)
+		}
+
+		seen[r.Index] = true
+
+		if r.Email != params[r.Index].Email {
+			t.Errorf("result %d: got email %q, want %q", r.Index, r.Email, params[r.Index].Email)
+		}
+
+		if !r.OK() {
+			failed++
+		}
+
+		return nil
+	})
+
+	chunks := nymeria.ChunkErrors(err)
+
+	if len(chunks) != 1 || chunks[0].Start != 100 || chunks[0].End != 200 {
+		t.Fatalf("got %v, want a *BulkError for inputs 100-199", err)
+	}
+
+	if !errors.Is(err, nymeria.ErrServerError) {
+		t.Errorf("%v does not match ErrServerError", err)
+	}
+
+	if m := spans.Metrics()["email.BulkVerifyStream"]; m.Errors != 1 || m.Records != 150 {
+		t.Errorf("got metrics %+v, want 1 error and 150 records", m)
+	}
+
+	if len(seen) != len(params) {
+		t.Errorf("got %d results, want %d", len(seen), len(params))
+	}
+
+	if failed != 100 {
+		t.Errorf("got %d failed results, want the 100 of the failed chunk", failed)
+	}
+}
+
+func TestBulkStreamCallbackError(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	errFull := errors.New("disk full")
+	in, done := produce(addresses(srv, 1000))
+	calls := 0
+
+	err := email.NewService(srv.Client(nymeria.WithBulkChunkSize(10))).BulkVerifyStream(context.Background(), in, func(r email.BulkVerifyResult) error {
+		calls++
+		return errFull
+	})
+
+	if !errors.Is(err, errFull) {
+		t.Fatalf("got %v, want the callback's error", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("callback called %d times after failing, want 1", calls)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("producer still blocked after the stream returned")
+	}
+}
@@ -0,0 +1,269 @@
+package nymeria
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Headers assumed to report credit consumption. The API documentation does
+// not name them, so they can be changed per client with WithUsageHeaders.
+const (
+	HeaderCreditsUsed      = "X-Credits-Used"
+	HeaderCreditsRemaining = "X-Credits-Remaining"
+	HeaderCreditsLimit     = "X-Credits-Limit"
+)
+
+// UsageHeaders names the response headers usage is read from. An empty name
+// is not read.
+type UsageHeaders struct {
+	Used      string
+	Remaining string
+	Limit     string
+}
+
+// DefaultUsageHeaders is used by clients not given WithUsageHeaders.
+var DefaultUsageHeaders = UsageHeaders{
+	Used:      HeaderCreditsUsed,
+	Remaining: HeaderCreditsRemaining,
+	Limit:     HeaderCreditsLimit,
+}
+
+// WithUsageHeaders reads credit usage from the given response headers instead
+// of DefaultUsageHeaders.
+func WithUsageHeaders(h UsageHeaders) Option {
+	return func(c *Client) error {
+		c.usageHeaders = &h
+		return nil
+	}
+}
+
+// Usage is the credit consumption reported by the API. Remaining and Limit are
+// nil when the server did not report them.
+type Usage struct {
+	Requests  int  `json:"requests"`
+	Used      int  `json:"used"`
+	Remaining *int `json:"remaining"`
+	Limit     *int `json:"limit"`
+}
+
+// add folds the usage of one response into u.
+func (u *Usage) add(r Usage) {
+	u.Requests += r.Requests
+	u.Used += r.Used
+
+	if r.Remaining != nil {
+		u.Remaining = r.Remaining
+	}
+
+	if r.Limit != nil {
+		u.Limit = r.Limit
+	}
+}
+
+// ParseUsage reads the DefaultUsageHeaders of a single response.
+func ParseUsage(h http.Header) Usage {
+	return DefaultUsageHeaders.Parse(h)
+}
+
+// Parse reads the credit headers of a single response.
+func (uh UsageHeaders) Parse(h http.Header) Usage {
+	u := Usage{Requests: 1}
+
+	if n, ok := headerInt(h, uh.Used); ok {
+		u.Used = n
+	}
+
+	if n, ok := headerInt(h, uh.Remaining); ok {
+		u.Remaining = &n
+	}
+
+	if n, ok := headerInt(h, uh.Limit); ok {
+		u.Limit = &n
+	}
+
+	return u
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	if len(key) == 0 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(h.Get(key))
+	return n, err == nil
+}
+
+type usageKey struct{}
+
+type tagKey struct{}
+
+// WithUsage returns a context that collects the usage of every request made
+// with it into u. Use it to get the credits spent by a single call:
+//
+//	var u nymeria.Usage
+//	p, err := person.EnrichContext(nymeria.WithUsage(ctx, &u), params)
+func WithUsage(ctx context.Context, u *Usage) context.Context {
+	return context.WithValue(ctx, usageKey{}, &usageSink{usage: u})
+}
+
+// Metered calls fn with a context that collects usage (see WithUsage) and
+// returns the usage of every request fn made alongside its result:
+//
+//	p, usage, err := nymeria.Metered(ctx, func(ctx context.Context) (*person.Person, error) {
+//		return person.EnrichContext(ctx, params)
+//	})
+func Metered[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, Usage, error) {
+	var u Usage
+
+	v, err := fn(WithUsage(ctx, &u))
+
+	return v, u, err
+}
+
+// WithTag returns a context whose requests are attributed to tag by the
+// client's UsageTracker.
+func WithTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, tagKey{}, tag)
+}
+
+type usageSink struct {
+	mu    sync.Mutex
+	usage *Usage
+}
+
+// recordUsage attributes the usage of resp to the sink and tracker for ctx.
+func (c *Client) recordUsage(ctx context.Context, endpoint string, resp *http.Response) {
+	headers := DefaultUsageHeaders
+
+	if c.usageHeaders != nil {
+		headers = *c.usageHeaders
+	}
+
+	u := headers.Parse(resp.Header)
+
+	if sink, ok := ctx.Value(usageKey{}).(*usageSink); ok {
+		sink.mu.Lock()
+		sink.usage.add(u)
+		sink.mu.Unlock()
+	}
+
+	if c.usage != nil {
+		tag, _ := ctx.Value(tagKey{}).(string)
+		c.usage.Record(endpoint, tag, u)
+	}
+}
+
+// WithUsageTracker aggregates the usage of every request made by the client
+// into t.
+func WithUsageTracker(t *UsageTracker) Option {
+	return func(c *Client) error {
+		c.usage = t
+		return nil
+	}
+}
+
+// UsageTotal is the usage aggregated for one endpoint and tag.
+type UsageTotal struct {
+	Endpoint string `json:"endpoint"`
+	Tag      string `json:"tag"`
+	Usage
+}
+
+// UsageTracker aggregates credit consumption per endpoint and per caller tag
+// (see WithTag). It is safe for concurrent use and its zero value is ready to
+// use.
+type UsageTracker struct {
+	mu     sync.Mutex
+	totals map[[2]string]*UsageTotal
+	total  Usage
+}
+
+// NewUsageTracker returns an empty UsageTracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{totals: map[[2]string]*UsageTotal{}}
+}
+
+// Record adds u to the totals of endpoint and tag.
+func (t *UsageTracker) Record(endpoint, tag string, u Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.totals == nil {
+		t.totals = map[[2]string]*UsageTotal{}
+	}
+
+	k := [2]string{endpoint, tag}
+
+	if _, ok := t.totals[k]; !ok {
+		t.totals[k] = &UsageTotal{Endpoint: endpoint, Tag: tag}
+	}
+
+	t.totals[k].add(u)
+	t.total.add(u)
+}
+
+// Totals returns the aggregated usage sorted by endpoint and tag.
+func (t *UsageTracker) Totals() []UsageTotal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var totals []UsageTotal
+
+	for _, v := range t.totals {
+		totals = append(totals, *v)
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Endpoint != totals[j].Endpoint {
+			return totals[i].Endpoint < totals[j].Endpoint
+		}
+
+		return totals[i].Tag < totals[j].Tag
+	})
+
+	return totals
+}
+
+// ByEndpoint returns the credits used per endpoint across all tags.
+func (t *UsageTracker) ByEndpoint() map[string]int {
+	return t.sum(0)
+}
+
+// ByTag returns the credits used per tag across all endpoints.
+func (t *UsageTracker) ByTag() map[string]int {
+	return t.sum(1)
+}
+
+func (t *UsageTracker) sum(field int) map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := map[string]int{}
+
+	for k, v := range t.totals {
+		m[k[field]] += v.Used
+	}
+
+	return m
+}
+
+// Total returns the usage across every endpoint and tag. Remaining and Limit
+// hold the most recently reported values.
+func (t *UsageTracker) Total() Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.total
+}
+
+// Reset discards everything recorded so far.
+func (t *UsageTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.totals = map[[2]string]*UsageTotal{}
+	t.total = Usage{}
+}
@@ -0,0 +1,151 @@
+package nymeria_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nymeria-io/nymeria.go"
+	"github.com/nymeria-io/nymeria.go/email"
+	"github.com/nymeria-io/nymeria.go/nymeriatest"
+)
+
+func intp(n int) *int {
+	return &n
+}
+
+func TestParseUsage(t *testing.T) {
+	custom := nymeria.UsageHeaders{Used: "X-Used", Remaining: ""}
+
+	tests := []struct {
+		name    string
+		headers nymeria.UsageHeaders
+		header  http.Header
+		want    nymeria.Usage
+	}{
+		{
+			name:    "all headers",
+			headers: nymeria.DefaultUsageHeaders,
+			header:  http.Header{"X-Credits-Used": {"2"}, "X-Credits-Remaining": {"98"}, "X-Credits-Limit": {"100"}},
+			want:    nymeria.Usage{Requests: 1, Used: 2, Remaining: intp(98), Limit: intp(100)},
+		},
+		{
+			name:    "no headers",
+			headers: nymeria.DefaultUsageHeaders,
+			header:  http.Header{},
+			want:    nymeria.Usage{Requests: 1},
+		},
+		{
+			name:    "malformed values",
+			headers: nymeria.DefaultUsageHeaders,
+			header:  http.Header{"X-Credits-Used": {"two"}, "X-Credits-Remaining": {""}},
+			want:    nymeria.Usage{Requests: 1},
+		},
+		{
+			name:    "custom names",
+			headers: custom,
+			header:  http.Header{"X-Used": {"3"}, "X-Credits-Used": {"9"}, "X-Credits-Remaining": {"9"}},
+			want:    nymeria.Usage{Requests: 1, Used: 3},
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.headers.Parse(test.header); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, got, test.want)
+		}
+	}
+
+	if got := nymeria.ParseUsage(tests[0].header); !reflect.DeepEqual(got, tests[0].want) {
+		t.Errorf("ParseUsage: got %+v, want %+v", got, tests[0].want)
+	}
+}
+
+func TestUsageTracker(t *testing.T) {
+	tracker := nymeria.NewUsageTracker()
+
+	tracker.Record("/person/enrich", "a", nymeria.Usage{Requests: 1, Used: 1, Remaining: intp(9)})
+	tracker.Record("/person/enrich", "b", nymeria.Usage{Requests: 1, Used: 2})
+	tracker.Record("/email/verify", "a", nymeria.Usage{Requests: 1, Used: 4, Remaining: intp(3)})
+	tracker.Record("/person/enrich", "a", nymeria.Usage{Requests: 1, Used: 1})
+
+	totals := tracker.Totals()
+
+	want := []struct {
+		endpoint, tag  string
+		requests, used int
+	}{
+		{"/email/verify", "a", 1, 4},
+		{"/person/enrich", "a", 2, 2},
+		{"/person/enrich", "b", 1, 2},
+	}
+
+	if len(totals) != len(want) {
+		t.Fatalf("got %d totals, want %d", len(totals), len(want))
+	}
+
+	for i, w := range want {
+		got := totals[i]
+
+		if got.Endpoint != w.endpoint || got.Tag != w.tag || got.Requests != w.requests || got.Used != w.used {
+			t.Errorf("total %d: got %+v, want %+v", i, got, w)
+		}
+	}
+
+	if got := tracker.ByEndpoint(); !reflect.DeepEqual(got, map[string]int{"/email/verify": 4, "/person/enrich": 4}) {
+		t.Errorf("ByEndpoint: got %v", got)
+	}
+
+	if got := tracker.ByTag(); !reflect.DeepEqual(got, map[string]int{"a": 6, "b": 2}) {
+		t.Errorf("ByTag: got %v", got)
+	}
+
+	total := tracker.Total()
+
+	if total.Requests != 4 || total.Used != 8 || total.Remaining == nil || *total.Remaining != 3 || total.Limit != nil {
+		t.Errorf("Total: got %+v", total)
+	}
+
+	tracker.Reset()
+
+	if len(tracker.Totals()) != 0 || tracker.Total().Used != 0 {
+		t.Error("Reset kept usage")
+	}
+}
+
+func TestUsageFromResponses(t *testing.T) {
+	srv := nymeriatest.NewServer()
+	defer srv.Close()
+
+	srv.AddVerification("dev@nymeria.io", email.Verification{Result: "valid"})
+	srv.Inject(nymeriatest.Fault{Header: http.Header{"X-Credits-Used": {"1"}, "X-Credits-Remaining": {"41"}}})
+
+	tracker := nymeria.NewUsageTracker()
+	svc := email.NewService(srv.Client(nymeria.WithUsageTracker(tracker)))
+
+	ctx := nymeria.WithTag(context.Background(), "import")
+
+	v, usage, err := nymeria.Metered(ctx, func(ctx context.Context) (*email.Verification, error) {
+		if _, err := svc.VerifyContext(ctx, "dev@nymeria.io"); err != nil {
+			return nil, err
+		}
+
+		return svc.VerifyContext(ctx, "dev@nymeria.io")
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Result != "valid" {
+		t.Errorf("got result %q, want valid", v.Result)
+	}
+
+	if usage.Requests != 2 || usage.Used != 2 || usage.Remaining == nil || *usage.Remaining != 41 {
+		t.Errorf("Metered: got %+v", usage)
+	}
+
+	if got := tracker.ByTag(); !reflect.DeepEqual(got, map[string]int{"import": 2}) {
+		t.Errorf("ByTag: got %v", got)
+	}
+}